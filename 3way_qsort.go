@@ -1,13 +1,21 @@
 package algo
 
 import (
+	"math/bits"
 	"math/rand"
+	"sort"
+
+	"golang.org/x/exp/constraints"
 )
 
 // 1 2 4 6 6 6 7 9 0 2 8 6
 //
 // - - - i     k   j     ^
 func Qsort3way(s []int) {
+	Qsort3wayOrdered(s)
+}
+
+func Qsort3wayOrdered[T constraints.Ordered](s []T) {
 	l := len(s)
 	if l <= 1 {
 		return
@@ -29,6 +37,270 @@ func Qsort3way(s []int) {
 		j++
 		k++
 	}
-	Qsort3way(s[:i])
-	Qsort3way(s[k:])
+	Qsort3wayOrdered(s[:i])
+	Qsort3wayOrdered(s[k:])
+}
+
+// cmp returns a negative number when a < b, a positive
+// number when a > b and zero when they are equal
+func Qsort3wayFunc[T any](s []T, cmp func(a, b T) int) {
+	(&qsorter[T]{cmp: cmp}).sort(s)
+}
+
+type PivotStrategy int
+
+const (
+	PivotRandom          PivotStrategy = iota
+	PivotMedianOf3                     // median of first, middle and last
+	PivotNinther                       // Tukey's median of three medians of three
+	PivotMedianOfMedians               // median of medians of five, linear worst case
+)
+
+// QsortOptions makes pivot selection reproducible, Source seeds
+// random pivots and falls back to the global source when nil.
+// A rand.Source is not safe for concurrent use, so do not share
+// one between concurrent sorts. Introspective bounds recursion
+// depth as in Qsort3wayIntro.
+type QsortOptions struct {
+	Pivot         PivotStrategy
+	Source        rand.Source
+	Introspective bool
+}
+
+func Qsort3wayWith[T constraints.Ordered](s []T, opts QsortOptions) {
+	Qsort3wayFuncWith(s, cmpOrdered[T], opts)
+}
+
+func Qsort3wayFuncWith[T any](s []T, cmp func(a, b T) int, opts QsortOptions) {
+	q := &qsorter[T]{cmp: cmp, pivot: opts.Pivot}
+	if opts.Source != nil {
+		q.rnd = rand.New(opts.Source)
+	}
+	if opts.Introspective {
+		q.introsort(s, 2*bits.Len(uint(len(s))))
+		return
+	}
+	q.sort(s)
+}
+
+// Qsort3wayIntro is the introspective Qsort3way, it switches to
+// heapsort past 2*log(n) partition levels and to insertion sort
+// for small slices, guaranteeing O(n log n) on any input
+func Qsort3wayIntro[T constraints.Ordered](s []T) {
+	Qsort3wayFuncWith(s, cmpOrdered[T], QsortOptions{Introspective: true})
+}
+
+func Qsort3wayIntroFunc[T any](s []T, cmp func(a, b T) int) {
+	Qsort3wayFuncWith(s, cmp, QsortOptions{Introspective: true})
+}
+
+func cmpOrdered[T constraints.Ordered](a, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+type qsorter[T any] struct {
+	cmp   func(a, b T) int
+	pivot PivotStrategy
+	rnd   *rand.Rand
+}
+
+func (q *qsorter[T]) intn(n int) int {
+	if q.rnd == nil {
+		return rand.Intn(n)
+	}
+	return q.rnd.Intn(n)
+}
+
+func (q *qsorter[T]) median3(s []T, a, b, c int) int {
+	if q.cmp(s[a], s[b]) < 0 {
+		if q.cmp(s[b], s[c]) < 0 {
+			return b
+		}
+		if q.cmp(s[a], s[c]) < 0 {
+			return c
+		}
+		return a
+	}
+	if q.cmp(s[a], s[c]) < 0 {
+		return a
+	}
+	if q.cmp(s[b], s[c]) < 0 {
+		return c
+	}
+	return b
+}
+
+func (q *qsorter[T]) choose(s []T) int {
+	l := len(s)
+	switch q.pivot {
+	case PivotMedianOf3:
+		return q.median3(s, 0, l/2, l-1)
+	case PivotNinther:
+		if l < 40 {
+			return q.median3(s, 0, l/2, l-1)
+		}
+		d := l / 8
+		return q.median3(s,
+			q.median3(s, 0, d, 2*d),
+			q.median3(s, l/2-d, l/2, l/2+d),
+			q.median3(s, l-1-2*d, l-1-d, l-1))
+	case PivotMedianOfMedians:
+		return q.medianOfMedians(s)
+	}
+	return q.intn(l)
+}
+
+// move the medians of groups of five to the front and select
+// their median, which is guaranteed to have at least 3/10 of
+// the elements on either side
+func (q *qsorter[T]) medianOfMedians(s []T) int {
+	if len(s) <= 5 {
+		q.insertion(s)
+		return len(s) / 2
+	}
+	m := 0
+	for i := 0; i < len(s); i += 5 {
+		end := i + 5
+		if end > len(s) {
+			end = len(s)
+		}
+		q.insertion(s[i:end])
+		mid := i + (end-i)/2
+		s[m], s[mid] = s[mid], s[m]
+		m++
+	}
+	q.nth(s[:m], m/2)
+	return m / 2
+}
+
+func (q *qsorter[T]) partition(s []T) (int, int) {
+	return q.partitionAt(s, q.choose(s))
+}
+
+// 3 way partition around s[n], on return s[:i] < pivot,
+// s[i:k] == pivot and s[k:] > pivot
+func (q *qsorter[T]) partitionAt(s []T, n int) (int, int) {
+	l := len(s)
+	s[n], s[0] = s[0], s[n]
+	pivot := s[0]
+	i, k, j := 0, 1, 1
+	for j < l {
+		c := q.cmp(s[j], pivot)
+		if c > 0 {
+			j++
+			continue
+		}
+		if c < 0 {
+			s[i], s[j] = s[j], s[i]
+			i++
+		}
+		s[j], s[k] = s[k], s[j]
+		j++
+		k++
+	}
+	return i, k
+}
+
+func (q *qsorter[T]) sort(s []T) {
+	if len(s) <= 1 {
+		return
+	}
+	i, k := q.partition(s)
+	q.sort(s[:i])
+	q.sort(s[k:])
+}
+
+// slices up to this size are insertion sorted
+const insertionCutoff = 12
+
+// recurse into the smaller side and loop on the larger one, so
+// the stack stays within log n frames, and give up on quicksort
+// for heapsort once depth runs out
+func (q *qsorter[T]) introsort(s []T, depth int) {
+	for len(s) > insertionCutoff {
+		if depth == 0 {
+			q.heapsort(s)
+			return
+		}
+		depth--
+		i, k := q.partition(s)
+		if i < len(s)-k {
+			q.introsort(s[:i], depth)
+			s = s[k:]
+		} else {
+			q.introsort(s[k:], depth)
+			s = s[:i]
+		}
+	}
+	q.insertion(s)
+}
+
+func (q *qsorter[T]) insertion(s []T) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && q.cmp(s[j], s[j-1]) < 0; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+func (q *qsorter[T]) sink(s []T, i int) {
+	for {
+		c := 2*i + 1
+		if c >= len(s) {
+			return
+		}
+		if c+1 < len(s) && q.cmp(s[c], s[c+1]) < 0 {
+			c++
+		}
+		if q.cmp(s[i], s[c]) >= 0 {
+			return
+		}
+		s[i], s[c] = s[c], s[i]
+		i = c
+	}
+}
+
+func (q *qsorter[T]) heapsort(s []T) {
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		q.sink(s, i)
+	}
+	for end := len(s) - 1; end > 0; end-- {
+		s[0], s[end] = s[end], s[0]
+		q.sink(s[:end], 0)
+	}
+}
+
+// Qsort3wayInterface sorts data with 3 way partitioning using
+// only Less and Swap. The pivot can not be copied out, but the
+// first element of the equal range data[lt:i] always holds it.
+func Qsort3wayInterface(data sort.Interface) {
+	qsort3wayInterface(data, 0, data.Len())
+}
+
+func qsort3wayInterface(data sort.Interface, lo, hi int) {
+	if hi-lo <= 1 {
+		return
+	}
+	data.Swap(lo, lo+rand.Intn(hi-lo))
+	lt, i, gt := lo, lo+1, hi
+	for i < gt {
+		if data.Less(i, lt) {
+			data.Swap(lt, i)
+			lt++
+			i++
+		} else if data.Less(lt, i) {
+			gt--
+			data.Swap(i, gt)
+		} else {
+			i++
+		}
+	}
+	qsort3wayInterface(data, lo, lt)
+	qsort3wayInterface(data, gt, hi)
 }
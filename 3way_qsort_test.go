@@ -2,7 +2,10 @@ package algo_test
 
 import (
 	"algo"
+	"fmt"
+	"math/bits"
 	"math/rand"
+	"sort"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -23,3 +26,173 @@ func TestAlgo_3wayQsort(t *testing.T) {
 		assert.Assert(t, s[i] <= s[i+1])
 	}
 }
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestAlgo_3wayQsortGeneric(t *testing.T) {
+	people := []person{}
+	for i := 0; i < 10000; i++ {
+		people = append(people, person{fmt.Sprint(i), rand.Intn(50)})
+	}
+	algo.Qsort3wayFunc(people, func(a, b person) int { return a.age - b.age })
+	for i := 0; i < len(people)-1; i++ {
+		assert.Assert(t, people[i].age <= people[i+1].age)
+	}
+
+	strs := []string{}
+	for i := 0; i < 10000; i++ {
+		strs = append(strs, fmt.Sprint(rand.Intn(100)))
+	}
+	algo.Qsort3wayOrdered(strs)
+	assert.Assert(t, sort.StringsAreSorted(strs))
+
+	floats := []float64{}
+	for i := 0; i < 10000; i++ {
+		floats = append(floats, float64(rand.Intn(10))/3)
+	}
+	algo.Qsort3wayInterface(sort.Float64Slice(floats))
+	assert.Assert(t, sort.Float64sAreSorted(floats))
+	algo.Qsort3wayInterface(sort.Reverse(sort.Float64Slice(floats)))
+	for i := 0; i < len(floats)-1; i++ {
+		assert.Assert(t, floats[i] >= floats[i+1])
+	}
+}
+
+func TestAlgo_3wayQsortOptions(t *testing.T) {
+	people := []person{}
+	for i := 0; i < 5000; i++ {
+		people = append(people, person{fmt.Sprint(i), rand.Intn(50)})
+	}
+	byAge := func(a, b person) int { return a.age - b.age }
+
+	for _, pivot := range []algo.PivotStrategy{algo.PivotRandom, algo.PivotMedianOf3, algo.PivotNinther} {
+		// equal keys end up in the same order for the same seed
+		first := append([]person{}, people...)
+		algo.Qsort3wayFuncWith(first, byAge, algo.QsortOptions{Pivot: pivot, Source: rand.NewSource(42)})
+		for i := 0; i < len(first)-1; i++ {
+			assert.Assert(t, first[i].age <= first[i+1].age)
+		}
+		second := append([]person{}, people...)
+		algo.Qsort3wayFuncWith(second, byAge, algo.QsortOptions{Pivot: pivot, Source: rand.NewSource(42)})
+		for i := range first {
+			assert.Equal(t, first[i].name, second[i].name)
+		}
+
+		ints := rand.Perm(5000)
+		algo.Qsort3wayWith(ints, algo.QsortOptions{Pivot: pivot})
+		assert.Assert(t, sort.IntsAreSorted(ints))
+		algo.Qsort3wayWith(ints, algo.QsortOptions{Pivot: pivot})
+		assert.Assert(t, sort.IntsAreSorted(ints))
+	}
+}
+
+// McIlroy's adversary, values are decided lazily during the sort
+// so that every pivot turns out to be nearly the smallest element
+type adversary struct {
+	val       []int
+	nsolid    int
+	candidate int
+	ncmp      int
+}
+
+func newAdversary(n int) *adversary {
+	a := &adversary{val: make([]int, n)}
+	for i := range a.val {
+		a.val[i] = n // gas, not yet decided
+	}
+	return a
+}
+
+func (a *adversary) cmp(x, y int) int {
+	a.ncmp++
+	gas := len(a.val)
+	if a.val[x] == gas && a.val[y] == gas {
+		if x == a.candidate {
+			a.val[x] = a.nsolid
+		} else {
+			a.val[y] = a.nsolid
+		}
+		a.nsolid++
+	}
+	if a.val[x] == gas {
+		a.candidate = x
+	} else if a.val[y] == gas {
+		a.candidate = y
+	}
+	return a.val[x] - a.val[y]
+}
+
+func TestAlgo_3wayQsortIntro(t *testing.T) {
+	n := 5000
+	inputs := map[string][]int{
+		"sorted":   make([]int, n),
+		"reversed": make([]int, n),
+		"equal":    make([]int, n),
+		"organ":    make([]int, n),
+		"random":   rand.Perm(n),
+	}
+	for i := 0; i < n; i++ {
+		inputs["sorted"][i] = i
+		inputs["reversed"][i] = n - i
+		inputs["organ"][i] = i
+		if i > n/2 {
+			inputs["organ"][i] = n - i
+		}
+	}
+	for name, in := range inputs {
+		s := append([]int{}, in...)
+		algo.Qsort3wayIntro(s)
+		assert.Assert(t, sort.IntsAreSorted(s), name)
+		for _, pivot := range []algo.PivotStrategy{algo.PivotMedianOf3, algo.PivotNinther} {
+			s := append([]int{}, in...)
+			algo.Qsort3wayWith(s, algo.QsortOptions{Pivot: pivot, Introspective: true})
+			assert.Assert(t, sort.IntsAreSorted(s), name)
+		}
+	}
+
+	for _, pivot := range []algo.PivotStrategy{algo.PivotMedianOf3, algo.PivotNinther} {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		plain := newAdversary(n)
+		algo.Qsort3wayFuncWith(append([]int{}, idx...), plain.cmp, algo.QsortOptions{Pivot: pivot})
+
+		intro := newAdversary(n)
+		algo.Qsort3wayFuncWith(idx, intro.cmp, algo.QsortOptions{Pivot: pivot, Introspective: true})
+		for i := 0; i < n-1; i++ {
+			assert.Assert(t, intro.val[idx[i]] <= intro.val[idx[i+1]])
+		}
+		// plain quicksort goes quadratic, introsort stays n log n
+		assert.Assert(t, plain.ncmp > n*n/20, plain.ncmp)
+		assert.Assert(t, intro.ncmp < 10*n*bits.Len(uint(n)), intro.ncmp)
+	}
+
+	people := []person{}
+	for i := 0; i < 1000; i++ {
+		people = append(people, person{fmt.Sprint(i), rand.Intn(50)})
+	}
+	algo.Qsort3wayIntroFunc(people, func(a, b person) int { return a.age - b.age })
+	for i := 0; i < len(people)-1; i++ {
+		assert.Assert(t, people[i].age <= people[i+1].age)
+	}
+}
+
+func BenchmarkQsort3wayPivot(b *testing.B) {
+	input := rand.New(rand.NewSource(1)).Perm(100000)
+	for _, bc := range []struct {
+		name  string
+		pivot algo.PivotStrategy
+	}{{"random", algo.PivotRandom}, {"median3", algo.PivotMedianOf3}, {"ninther", algo.PivotNinther}} {
+		b.Run(bc.name, func(b *testing.B) {
+			s := make([]int, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(s, input)
+				algo.Qsort3wayWith(s, algo.QsortOptions{Pivot: bc.pivot, Source: rand.NewSource(1)})
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package algo
+
+import (
+	"bufio"
+	"io"
+)
+
+// trie node with failure link, fail points to the node of the
+// longest proper suffix of this node's path which is also in the
+// trie, out lists patterns ending here including those reached
+// through the failure chain, longest pattern first
+type ahoNode struct {
+	next map[byte]*ahoNode
+	fail *ahoNode
+	out  []int
+}
+
+// Match is a pattern occurrence, Pattern indexes the pattern
+// list and Offset is the byte offset of the match start
+type Match struct {
+	Pattern int
+	Offset  int
+}
+
+// AhoCorasick matches a set of patterns in a single pass over
+// the input, it is the multi pattern version of KMP where the
+// failure links play the role of the bktrack table
+type AhoCorasick struct {
+	root     *ahoNode
+	patterns []string
+}
+
+// empty patterns never match
+func NewAhoCorasick(patterns []string) *AhoCorasick {
+	a := &AhoCorasick{
+		root:     &ahoNode{next: map[byte]*ahoNode{}},
+		patterns: patterns,
+	}
+	for i, p := range patterns {
+		if p == "" {
+			continue
+		}
+		n := a.root
+		for j := 0; j < len(p); j++ {
+			c := n.next[p[j]]
+			if c == nil {
+				c = &ahoNode{next: map[byte]*ahoNode{}}
+				n.next[p[j]] = c
+			}
+			n = c
+		}
+		n.out = append(n.out, i)
+	}
+
+	// breadth first, so failure links of shallower nodes are ready
+	a.root.fail = a.root
+	queue := []*ahoNode{a.root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for c, child := range n.next {
+			f := n.fail
+			for f != a.root && f.next[c] == nil {
+				f = f.fail
+			}
+			if fc := f.next[c]; fc != nil && fc != child {
+				child.fail = fc
+			} else {
+				child.fail = a.root
+			}
+			child.out = append(child.out, child.fail.out...)
+			queue = append(queue, child)
+		}
+	}
+
+	return a
+}
+
+func (a *AhoCorasick) step(n *ahoNode, c byte) *ahoNode {
+	for n != a.root && n.next[c] == nil {
+		n = n.fail
+	}
+	if next := n.next[c]; next != nil {
+		return next
+	}
+	return a.root
+}
+
+// matches are ordered by end offset, longer patterns first
+func (a *AhoCorasick) FindAll(s string) []Match {
+	matches := []Match{}
+	n := a.root
+	for i := 0; i < len(s); i++ {
+		n = a.step(n, s[i])
+		for _, p := range n.out {
+			matches = append(matches, Match{p, i + 1 - len(a.patterns[p])})
+		}
+	}
+	return matches
+}
+
+// Scan streams r through the automaton and calls fn for every
+// match as soon as its last byte is read, offsets are counted
+// from the start of the stream
+func (a *AhoCorasick) Scan(r io.Reader, fn func(Match)) error {
+	br := bufio.NewReader(r)
+	n := a.root
+	for i := 0; ; i++ {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		n = a.step(n, c)
+		for _, p := range n.out {
+			fn(Match{p, i + 1 - len(a.patterns[p])})
+		}
+	}
+}
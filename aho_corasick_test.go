@@ -0,0 +1,72 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"gotest.tools/v3/assert"
+)
+
+func naiveMatches(s string, patterns []string) []algo.Match {
+	matches := []algo.Match{}
+	for p, pat := range patterns {
+		if pat == "" {
+			continue
+		}
+		for i := 0; i+len(pat) <= len(s); i++ {
+			if s[i:i+len(pat)] == pat {
+				matches = append(matches, algo.Match{Pattern: p, Offset: i})
+			}
+		}
+	}
+	return matches
+}
+
+func sortMatches(m []algo.Match) {
+	sort.Slice(m, func(i, j int) bool {
+		if m[i].Offset != m[j].Offset {
+			return m[i].Offset < m[j].Offset
+		}
+		return m[i].Pattern < m[j].Pattern
+	})
+}
+
+func TestAlgo_AhoCorasick(t *testing.T) {
+	ac := algo.NewAhoCorasick([]string{"he", "she", "his", "hers", ""})
+	assert.DeepEqual(t, ac.FindAll("ushers"), []algo.Match{
+		{Pattern: 1, Offset: 1}, {Pattern: 0, Offset: 2}, {Pattern: 3, Offset: 2}})
+	assert.DeepEqual(t, ac.FindAll(""), []algo.Match{})
+
+	patterns := []string{}
+	for i := 0; i < 50; i++ {
+		b := make([]byte, 1+rand.Intn(5))
+		for j := range b {
+			b[j] = "abc"[rand.Intn(3)]
+		}
+		patterns = append(patterns, string(b))
+	}
+	b := make([]byte, 5000)
+	for j := range b {
+		b[j] = "abc"[rand.Intn(3)]
+	}
+	text := string(b)
+
+	ac = algo.NewAhoCorasick(patterns)
+	expected := naiveMatches(text, patterns)
+	sortMatches(expected)
+	got := ac.FindAll(text)
+	sortMatches(got)
+	assert.DeepEqual(t, got, expected)
+
+	streamed := []algo.Match{}
+	r := iotest.OneByteReader(strings.NewReader(text))
+	assert.NilError(t, ac.Scan(r, func(m algo.Match) {
+		streamed = append(streamed, m)
+	}))
+	sortMatches(streamed)
+	assert.DeepEqual(t, streamed, expected)
+}
@@ -0,0 +1,115 @@
+package algo
+
+// FindApprox returns the end offsets of the substrings of text
+// with the length of pattern that differ from it in at most k
+// positions, each match is text[end-len(pattern):end]. Ends are
+// used, as in FindApproxEdits, since the start of an edit distance
+// match is not fixed. Comparison of a window stops once k is exceeded.
+func FindApprox(text, pattern string, k int) []int {
+	pos, m := []int{}, len(pattern)
+	if k < 0 {
+		return pos
+	}
+	for i := 0; i+m <= len(text); i++ {
+		miss := 0
+		for j := 0; j < m && miss <= k; j++ {
+			if text[i+j] != pattern[j] {
+				miss++
+			}
+		}
+		if miss <= k {
+			pos = append(pos, i+m)
+		}
+	}
+	return pos
+}
+
+// pattern bits are split in 64 bit blocks, the last one
+// holding the top row bit at position (m-1) % 64
+type myersBlock struct {
+	pv, mv uint64 // vertical +1/-1 deltas of the dp column
+}
+
+// one step of Myers' block algorithm: update the deltas of block b
+// for text char eq bits, given the horizontal delta entering the
+// block at the top and returning the one leaving at the bottom
+func (blk *myersBlock) advance(eq uint64, hin int, hibit uint64) int {
+	pv, mv := blk.pv, blk.mv
+	xv := eq | mv
+	if hin < 0 {
+		eq |= 1
+	}
+	xh := (((eq & pv) + pv) ^ pv) | eq
+	ph := mv | ^(xh | pv)
+	mh := pv & xh
+
+	hout := 0
+	if ph&hibit != 0 {
+		hout = 1
+	} else if mh&hibit != 0 {
+		hout = -1
+	}
+
+	ph <<= 1
+	mh <<= 1
+	if hin < 0 {
+		mh |= 1
+	} else if hin > 0 {
+		ph |= 1
+	}
+	blk.pv = mh | ^(xv | ph)
+	blk.mv = ph & xv
+	return hout
+}
+
+// FindApproxEdits returns the end offsets in text where some
+// substring ending there is within k edits (Levenshtein distance)
+// of pattern, so every end reported by FindApprox is reported too. It runs Myers' bit-parallel algorithm, keeping
+// only the current dp column encoded as bit vectors.
+func FindApproxEdits(text, pattern string, k int) []int {
+	pos, m := []int{}, len(pattern)
+	if k < 0 {
+		return pos
+	}
+	if m == 0 {
+		return boundaries(len(text), -1)
+	}
+
+	nb := (m + 63) / 64
+	peq := [256][]uint64{}
+	for i := 0; i < m; i++ {
+		c := pattern[i]
+		if peq[c] == nil {
+			peq[c] = make([]uint64, nb)
+		}
+		peq[c][i/64] |= 1 << (i % 64)
+	}
+	zero := make([]uint64, nb)
+
+	blocks := make([]myersBlock, nb)
+	for b := range blocks {
+		blocks[b].pv = ^uint64(0)
+	}
+	lastbit := uint64(1) << ((m - 1) % 64)
+	score := m
+	if score <= k {
+		pos = append(pos, 0)
+	}
+
+	for j := 0; j < len(text); j++ {
+		eq := peq[text[j]]
+		if eq == nil {
+			eq = zero
+		}
+		// the top row is all zeros, a match may start anywhere
+		h := 0
+		for b := 0; b < nb-1; b++ {
+			h = blocks[b].advance(eq[b], h, 1<<63)
+		}
+		score += blocks[nb-1].advance(eq[nb-1], h, lastbit)
+		if score <= k {
+			pos = append(pos, j+1)
+		}
+	}
+	return pos
+}
@@ -0,0 +1,82 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// Sellers dp, column by column
+func naiveApproxEdits(text, pat string, k int) []int {
+	col := make([]int, len(pat)+1)
+	for i := range col {
+		col[i] = i
+	}
+	pos := []int{}
+	if col[len(pat)] <= k {
+		pos = append(pos, 0)
+	}
+	for j := 0; j < len(text); j++ {
+		diag := col[0]
+		for i := 1; i <= len(pat); i++ {
+			cost := 1
+			if pat[i-1] == text[j] {
+				cost = 0
+			}
+			v := diag + cost
+			if col[i]+1 < v {
+				v = col[i] + 1
+			}
+			if col[i-1]+1 < v {
+				v = col[i-1] + 1
+			}
+			diag, col[i] = col[i], v
+		}
+		if col[len(pat)] <= k {
+			pos = append(pos, j+1)
+		}
+	}
+	return pos
+}
+
+func TestAlgo_FindApprox(t *testing.T) {
+	assert.DeepEqual(t, algo.FindApprox("abcabd", "abd", 0), []int{6})
+	assert.DeepEqual(t, algo.FindApprox("abcabd", "abd", 1), []int{3, 6})
+	assert.DeepEqual(t, algo.FindApprox("xabcx", "abc", 0), []int{4})
+	assert.DeepEqual(t, algo.FindApproxEdits("xabcx", "abc", 0), []int{4})
+	assert.DeepEqual(t, algo.FindApprox("abc", "abd", -1), []int{})
+	assert.DeepEqual(t, algo.FindApproxEdits("the servey said", "survey", 1), []int{10})
+	assert.DeepEqual(t, algo.FindApproxEdits("abc", "", 0), []int{0, 1, 2, 3})
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 300; i++ {
+		text := randText(r, "acgt", r.Intn(300))
+		pat := randText(r, "acgt", 1+r.Intn(150))
+		k := r.Intn(1 + len(pat)/2)
+		assert.DeepEqual(t, algo.FindApproxEdits(text, pat, k), naiveApproxEdits(text, pat, k))
+
+		hamming := []int{}
+		for j := 0; j+len(pat) <= len(text); j++ {
+			miss := 0
+			for l := range pat {
+				if text[j+l] != pat[l] {
+					miss++
+				}
+			}
+			if miss <= k {
+				hamming = append(hamming, j+len(pat))
+			}
+		}
+		assert.DeepEqual(t, algo.FindApprox(text, pat, k), hamming)
+		// a hamming match is an edit match ending at the same offset
+		edits := map[int]bool{}
+		for _, end := range algo.FindApproxEdits(text, pat, k) {
+			edits[end] = true
+		}
+		for _, end := range hamming {
+			assert.Assert(t, edits[end], end)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package algo
+
+import (
+	"container/heap"
+	"math"
+)
+
+// acWeight is the value of a weighted ternary search tree node,
+// best caches the highest weight of any key below the node,
+// siblings included, so the top completions can be found
+// without a full subtree walk
+type acWeight struct {
+	weight float64
+	best   float64
+}
+
+type acNode = tstNode[acWeight]
+
+func fixBest(n *acNode) {
+	n.val.best = math.Inf(-1)
+	if n.isString {
+		n.val.best = n.val.weight
+	}
+	for _, c := range []*acNode{n.left, n.mid, n.right} {
+		if c != nil && c.val.best > n.val.best {
+			n.val.best = c.val.best
+		}
+	}
+}
+
+// search frontier entry, either a complete key or a
+// subtree rooted at node whose keys all start with prefix
+type acItem struct {
+	prefix string
+	node   *acNode
+	prio   float64
+}
+
+type acQueue []acItem
+
+func (q acQueue) Len() int { return len(q) }
+
+// complete keys win ties so they are emitted before
+// expanding subtrees that can not beat them
+func (q acQueue) Less(i, j int) bool {
+	if q[i].prio != q[j].prio {
+		return q[i].prio > q[j].prio
+	}
+	return q[i].node == nil && q[j].node != nil
+}
+
+func (q acQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *acQueue) Push(x any) { *q = append(*q, x.(acItem)) }
+
+func (q *acQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+func (q *acQueue) pushNode(n *acNode, prefix string) {
+	if n != nil {
+		heap.Push(q, acItem{prefix: prefix, node: n, prio: n.val.best})
+	}
+}
+
+// Autocomplete stores weighted keys and answers the
+// highest weighted completions of a prefix
+type Autocomplete struct {
+	root *acNode
+	size int
+}
+
+func (a *Autocomplete) IsEmpty() bool { return a.root == nil }
+
+func (a *Autocomplete) Size() int { return a.size }
+
+func (a *Autocomplete) Weight(key string) (float64, bool) {
+	if key == "" {
+		return 0, false
+	}
+	n := a.root.get([]rune(key), 0)
+	if n == nil || !n.isString {
+		return 0, false
+	}
+	return n.val.weight, true
+}
+
+// add key or update weight of an existing key
+func (a *Autocomplete) PutWeighted(key string, w float64) error {
+	if key == "" {
+		return emptyKeyErr
+	}
+	if _, ok := a.Weight(key); !ok {
+		a.size++
+	}
+	a.root = a.root.add([]rune(key), 0, acWeight{weight: w}, fixBest)
+	return nil
+}
+
+func (a *Autocomplete) Delete(key string) error {
+	if key == "" {
+		return emptyKeyErr
+	}
+	if _, ok := a.Weight(key); !ok {
+		return nil
+	}
+	a.size--
+	a.root = a.root.remove([]rune(key), 0, fixBest)
+	return nil
+}
+
+func (a *Autocomplete) KeysWithPrefix(prefix string) []string {
+	if prefix == "" {
+		return a.root.collect(nil, []string{})
+	}
+	pre := []rune(prefix)
+	n := a.root.get(pre, 0)
+	if n == nil {
+		return nil
+	}
+	results := []string{}
+	if n.isString {
+		results = append(results, prefix)
+	}
+	return n.mid.collect(pre, results)
+}
+
+// TopK returns at most k keys starting with prefix, highest weight
+// first. Subtrees are expanded best first by their cached weight,
+// so only the branches leading to the results are visited.
+func (a *Autocomplete) TopK(prefix string, k int) []string {
+	q := &acQueue{}
+	if prefix == "" {
+		q.pushNode(a.root, "")
+	} else {
+		n := a.root.get([]rune(prefix), 0)
+		if n == nil {
+			return nil
+		}
+		if n.isString {
+			heap.Push(q, acItem{prefix: prefix, prio: n.val.weight})
+		}
+		q.pushNode(n.mid, prefix)
+	}
+
+	results := []string{}
+	for q.Len() > 0 && len(results) < k {
+		it := heap.Pop(q).(acItem)
+		n := it.node
+		if n == nil {
+			results = append(results, it.prefix)
+			continue
+		}
+		q.pushNode(n.left, it.prefix)
+		q.pushNode(n.right, it.prefix)
+		key := it.prefix + string(n.c)
+		if n.isString {
+			heap.Push(q, acItem{prefix: key, prio: n.val.weight})
+		}
+		q.pushNode(n.mid, key)
+	}
+	return results
+}
@@ -0,0 +1,117 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_Autocomplete(t *testing.T) {
+	ac := algo.Autocomplete{}
+	assert.Assert(t, ac.IsEmpty())
+	assert.Error(t, ac.PutWeighted("", 1), "key must not be empty")
+
+	assert.NilError(t, ac.PutWeighted("go", 5))
+	assert.NilError(t, ac.PutWeighted("golang", 10))
+	assert.NilError(t, ac.PutWeighted("google", 50))
+	assert.NilError(t, ac.PutWeighted("gopher", 20))
+	assert.NilError(t, ac.PutWeighted("rust", 30))
+	assert.DeepEqual(t, ac.TopK("go", 2), []string{"google", "gopher"})
+	assert.DeepEqual(t, ac.TopK("", 3), []string{"google", "rust", "gopher"})
+	assert.DeepEqual(t, ac.TopK("go", 10), []string{"google", "gopher", "golang", "go"})
+	assert.Assert(t, ac.TopK("x", 1) == nil)
+
+	assert.NilError(t, ac.PutWeighted("go", 100))
+	assert.DeepEqual(t, ac.TopK("go", 1), []string{"go"})
+	assert.NilError(t, ac.Delete("go"))
+	assert.DeepEqual(t, ac.TopK("go", 1), []string{"google"})
+	assert.Equal(t, ac.Size(), 4)
+
+	weights := map[string]float64{"golang": 10, "google": 50, "gopher": 20, "rust": 30}
+	for i := 0; i < 3000; i++ {
+		k := fmt.Sprintf("%x", rand.Intn(10000))
+		w := rand.Float64()
+		weights[k] = w
+		assert.NilError(t, ac.PutWeighted(k, w))
+	}
+	for _, p := range []string{"", "1", "a", "ff", "12"} {
+		keys := ac.KeysWithPrefix(p)
+		sort.SliceStable(keys, func(i, j int) bool {
+			return weights[keys[i]] > weights[keys[j]]
+		})
+		if len(keys) > 10 {
+			keys = keys[:10]
+		}
+		assert.DeepEqual(t, ac.TopK(p, 10), keys)
+	}
+
+	for k := range weights {
+		assert.NilError(t, ac.Delete(k))
+		_, ok := ac.Weight(k)
+		assert.Assert(t, !ok)
+	}
+	assert.Assert(t, ac.IsEmpty())
+}
+
+func TestAlgo_AutocompleteChurn(t *testing.T) {
+	ac := algo.Autocomplete{}
+	weights := map[string]float64{}
+	rnd := rand.New(rand.NewSource(1))
+	randKey := func(n int) string {
+		k := make([]byte, n)
+		for i := range k {
+			k[i] = "abcd"[rnd.Intn(4)]
+		}
+		return string(k)
+	}
+	for i := 0; i < 20000; i++ {
+		k := randKey(1 + rnd.Intn(4))
+		if rnd.Intn(2) == 0 {
+			weights[k] = rnd.Float64()
+			assert.NilError(t, ac.PutWeighted(k, weights[k]))
+		} else {
+			delete(weights, k)
+			assert.NilError(t, ac.Delete(k))
+		}
+	}
+	// thin out the keys so many prefixes become dead
+	for k := range weights {
+		if rnd.Intn(10) > 0 {
+			delete(weights, k)
+			assert.NilError(t, ac.Delete(k))
+		}
+	}
+	assert.Equal(t, ac.Size(), len(weights))
+
+	for i := 0; i < 300; i++ {
+		p := randKey(1 + rnd.Intn(3))
+		expected := []string{}
+		for k := range weights {
+			if strings.HasPrefix(k, p) {
+				expected = append(expected, k)
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool { return weights[expected[i]] > weights[expected[j]] })
+		if len(expected) > 5 {
+			expected = expected[:5]
+		}
+		if len(expected) == 0 {
+			// deleted keys leave no dead nodes behind
+			assert.Assert(t, ac.KeysWithPrefix(p) == nil, p)
+			assert.Assert(t, ac.TopK(p, 5) == nil, p)
+			continue
+		}
+		assert.DeepEqual(t, ac.TopK(p, 5), expected)
+	}
+
+	for k := range weights {
+		assert.NilError(t, ac.Delete(k))
+	}
+	assert.Assert(t, ac.IsEmpty())
+	assert.Assert(t, ac.KeysWithPrefix("a") == nil)
+}
@@ -0,0 +1,315 @@
+package algo
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// runs of this size are insertion sorted before merging
+const blockRun = 16
+
+// BlockMergeSort is a stable sort in O(n log n) time with O(1)
+// extra space, a bottom up merge sort where runs are merged in
+// place in the manner of GrailSort. It first moves about 3√n
+// distinct keys to the front, they stand in for the merge buffer
+// and tag the blocks of √n elements a merge is cut into. Blocks
+// of both runs are selection sorted by their first element, using
+// the tags to break ties, then merged locally through the buffer.
+// With too few distinct keys blocks grow and local merges rotate
+// instead, which stays cheap since equal elements move together.
+// The keys are sorted and merged back at the end.
+func BlockMergeSort[T constraints.Ordered](s []T) {
+	BlockMergeSortFunc(s, cmpOrdered[T])
+}
+
+func BlockMergeSortFunc[T any](s []T, cmp func(a, b T) int) {
+	n := len(s)
+	q := &qsorter[T]{cmp: cmp}
+	if n <= blockRun {
+		q.insertion(s)
+		return
+	}
+	need := 0
+	for l := blockRun; l < n; l *= 2 {
+		k := blockSize(l)
+		if k+2*l/k > need {
+			need = k + 2*l/k
+		}
+	}
+	d := collectKeys(s, need, cmp)
+	for a := d; a < n; a += blockRun {
+		b := a + blockRun
+		if b > n {
+			b = n
+		}
+		q.insertion(s[a:b])
+	}
+
+	// keys are sorted until used as a buffer
+	unsorted := false
+	for l := blockRun; l < n-d; l *= 2 {
+		k, buf, tags := l, 0, 0
+		switch {
+		case l <= d:
+			// merge whole runs through a buffer of l keys
+			buf = l
+		case blockSize(l)+2*l/blockSize(l) <= d:
+			k = blockSize(l)
+			buf, tags = k, d-k-2*l/k
+		default:
+			// tags only, blocks grow until there are enough tags
+			for k = blockSize(l); k*d < 2*l; k *= 2 {
+			}
+		}
+		if k < l && unsorted {
+			q.insertion(s[:d])
+			unsorted = false
+		}
+		unsorted = unsorted || buf > 0
+
+		for p := d; p < n; p += 2 * l {
+			if p+l >= n {
+				shiftLeft(s, p, n-p, buf)
+				break
+			}
+			l2 := n - p - l
+			if l2 > l {
+				l2 = l
+			}
+			switch {
+			case cmp(s[p+l-1], s[p+l]) <= 0:
+				shiftLeft(s, p, l+l2, buf)
+			case k >= l:
+				m := &blockMerger[T]{s: s, cmp: cmp, buf: buf, start: p}
+				m.add(l, true)
+				m.add(l2, false)
+				m.flush()
+			default:
+				blockMerge(s, p, l, l2, k, buf, tags, cmp)
+			}
+		}
+		// bring the buffer back in front of the data
+		rotate(s, d-buf, n-buf, n)
+	}
+
+	if unsorted {
+		q.insertion(s[:d])
+	}
+	// keys are the first of their equals, so they win ties
+	m := &blockMerger[T]{s: s, cmp: cmp}
+	m.add(d, true)
+	m.add(n-d, false)
+}
+
+// smallest power of two k with k² >= 2l, so a merge of two runs of
+// l has at most k blocks and selection sorting them costs O(l)
+func blockSize(l int) int {
+	k := 1
+	for k*k < 2*l {
+		k *= 2
+	}
+	return k
+}
+
+// collect up to need distinct keys at the front of s in sorted
+// order and return how many were found. The keys are the first
+// occurrence of their value and the others keep their order, so
+// the keys region is rolled along s instead of moving elements
+// past it one by one.
+func collectKeys[T any](s []T, need int, cmp func(a, b T) int) int {
+	h, found := 0, 1
+	for i := 1; i < len(s) && found < need; i++ {
+		r := sort.Search(found, func(j int) bool { return cmp(s[h+j], s[i]) >= 0 })
+		if r < found && cmp(s[h+r], s[i]) == 0 {
+			continue
+		}
+		rotate(s, h, h+found, i)
+		h = i - found
+		rotate(s, h+r, i, i+1)
+		found++
+	}
+	rotate(s, 0, h, h+found)
+	return found
+}
+
+// move s[p:p+n] over the buf elements before it, which end up
+// after it in some order
+func shiftLeft[T any](s []T, p, n, buf int) {
+	if buf == 0 {
+		return
+	}
+	for i := p; i < p+n; i++ {
+		s[i-buf], s[i] = s[i], s[i-buf]
+	}
+}
+
+// merge the run s[p:p+l] with the following s[p+l:p+l+l2], cutting
+// them into blocks of k tagged by s[tags:], and through the buffer
+// of buf elements before s[p] when buf is not 0
+func blockMerge[T any](s []T, p, l, l2, k, buf, tags int, cmp func(a, b T) int) {
+	na, nb, tail := l/k, l2/k, l2%k
+	t := na + nb
+	blk := func(i int) int { return p + i*k }
+
+	// first tag of a b block, a tags are less
+	var mid T
+	if nb > 0 {
+		mid = s[tags+na]
+		for i := 0; i < t; i++ {
+			min := i
+			for j := i + 1; j < t; j++ {
+				c := cmp(s[blk(j)], s[blk(min)])
+				if c < 0 || (c == 0 && cmp(s[tags+j], s[tags+min]) < 0) {
+					min = j
+				}
+			}
+			if min != i {
+				swapRange(s, blk(i), blk(min), k)
+				s[tags+i], s[tags+min] = s[tags+min], s[tags+i]
+			}
+		}
+	}
+	isA := func(i int) bool { return nb == 0 || cmp(s[tags+i], mid) < 0 }
+
+	// the partial last block of b goes before the trailing a blocks
+	// whose first element is greater than its own
+	c := 0
+	if tail > 0 {
+		for c < t && isA(t-1-c) && cmp(s[blk(t-1-c)], s[blk(t)]) > 0 {
+			c++
+		}
+		rotate(s, blk(t-c), blk(t), blk(t)+tail)
+	}
+
+	m := &blockMerger[T]{s: s, cmp: cmp, buf: buf, start: p}
+	for i := 0; i < t-c; i++ {
+		m.add(k, isA(i))
+	}
+	if tail > 0 {
+		m.add(tail, false)
+	}
+	for i := 0; i < c; i++ {
+		m.add(k, true)
+	}
+	m.flush()
+
+	if nb > 0 {
+		(&qsorter[T]{cmp: cmp}).insertion(s[tags : tags+t])
+	}
+}
+
+// blockMerger merges a sequence of segments, each from one of two
+// sorted runs a and b, where every element is preceded by the
+// ones it follows in the merged order except those of the segment
+// just before it. It keeps the rest, the tail of the output so far
+// that may still be overtaken, and merges it with each segment
+// coming from the other run. Elements of a go first among equals.
+type blockMerger[T any] struct {
+	s   []T
+	cmp func(a, b T) int
+	// buffer size, the buffer lies right before the rest, without
+	// one rest and segment are merged by rotations
+	buf      int
+	start, n int
+	isA      bool
+}
+
+// add the segment of n elements following the rest
+func (m *blockMerger[T]) add(n int, isA bool) {
+	if m.n == 0 || isA == m.isA {
+		m.flush()
+		m.n, m.isA = n, isA
+		return
+	}
+	if m.buf > 0 {
+		m.mergeBuffered(n)
+	} else {
+		m.mergeRotating(n)
+	}
+}
+
+// the rest is final, move it in front of the buffer
+func (m *blockMerger[T]) flush() {
+	shiftLeft(m.s, m.start, m.n, m.buf)
+	m.start += m.n
+	m.n = 0
+}
+
+func (m *blockMerger[T]) first(c int) bool { return c < 0 || (c == 0 && m.isA) }
+
+// merge forward into the buffer swapping elements out, the
+// segment must not be larger than the buffer
+func (m *blockMerger[T]) mergeBuffered(n int) {
+	s := m.s
+	o, i, j := m.start-m.buf, m.start, m.start+m.n
+	xEnd, yEnd := j, j+n
+	for i < xEnd && j < yEnd {
+		if m.first(m.cmp(s[i], s[j])) {
+			s[o], s[i] = s[i], s[o]
+			i++
+		} else {
+			s[o], s[j] = s[j], s[o]
+			j++
+		}
+		o++
+	}
+	if i == xEnd {
+		m.start, m.n, m.isA = j, yEnd-j, !m.isA
+		return
+	}
+	// the segment ran out, move the rest back next to the buffer
+	for x := xEnd - 1; x >= i; x-- {
+		s[x], s[x+n] = s[x+n], s[x]
+	}
+	m.start, m.n = i+n, xEnd-i
+}
+
+// rotate the elements of the segment that go before the head of
+// the rest in front of it, skip the rest up to the head of the
+// segment and repeat. The head of the rest grows every round so
+// there are no more rounds than distinct elements in the rest.
+func (m *blockMerger[T]) mergeRotating(n int) {
+	s, a, mid := m.s, m.start, m.start+m.n
+	end := mid + n
+	for {
+		x := s[a]
+		cnt := sort.Search(end-mid, func(j int) bool { return m.first(m.cmp(x, s[mid+j])) })
+		rotate(s, a, mid, mid+cnt)
+		a, mid = a+cnt, mid+cnt
+		if mid == end {
+			m.start, m.n = a, end-a
+			return
+		}
+		y := s[mid]
+		a += sort.Search(mid-a, func(j int) bool { return !m.first(m.cmp(s[a+j], y)) })
+		if a == mid {
+			m.start, m.n, m.isA = mid, end-mid, !m.isA
+			return
+		}
+	}
+}
+
+func swapRange[T any](s []T, a, b, n int) {
+	for i := 0; i < n; i++ {
+		s[a+i], s[b+i] = s[b+i], s[a+i]
+	}
+}
+
+// rotate s[a:m] and s[m:b] into s[m:b] s[a:m] by block swaps
+func rotate[T any](s []T, a, m, b int) {
+	if a == m || m == b {
+		return
+	}
+	i, j := m-a, b-m
+	for i != j {
+		if i > j {
+			swapRange(s, m-i, m, j)
+			i -= j
+		} else {
+			swapRange(s, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	swapRange(s, m-i, m, i)
+}
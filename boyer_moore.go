@@ -0,0 +1,114 @@
+package algo
+
+// BoyerMoore compares the pattern right to left, on a mismatch
+// it shifts by the larger of the bad character and good suffix rules
+type BoyerMoore struct {
+	pat    []byte
+	last   [256]int // last position of each char in pat, -1 if absent
+	suffix []int    // shift when pat[j:] matched and pat[j-1] mismatched
+}
+
+func NewBoyerMoore(pattern string) (*BoyerMoore, error) {
+	if pattern == "" {
+		return nil, emptyPatternErr
+	}
+	bm := &BoyerMoore{pat: []byte(pattern)}
+	for i := range bm.last {
+		bm.last[i] = -1
+	}
+	for i, c := range bm.pat {
+		bm.last[c] = i
+	}
+	bm.suffix = goodSuffix(bm.pat)
+	return bm, nil
+}
+
+// strong good suffix table, f[i] is the start of the widest
+// border of pat[i:], borders are found like in bktrack but
+// scanning the pattern from the right
+func goodSuffix(pat []byte) []int {
+	m := len(pat)
+	f, shift := make([]int, m+1), make([]int, m+1)
+	i, j := m, m+1
+	f[i] = j
+	for i > 0 {
+		for j <= m && pat[i-1] != pat[j-1] {
+			if shift[j] == 0 {
+				shift[j] = j - i
+			}
+			j = f[j]
+		}
+		i--
+		j--
+		f[i] = j
+	}
+
+	// suffixes without a reoccurrence shift by the widest border
+	j = f[0]
+	for i := 0; i <= m; i++ {
+		if shift[i] == 0 {
+			shift[i] = j
+		}
+		if i == j {
+			j = f[j]
+		}
+	}
+	return shift
+}
+
+func (bm *BoyerMoore) FindAll(s string) []int {
+	pos, m := []int{}, len(bm.pat)
+	for i := 0; i <= len(s)-m; {
+		j := m - 1
+		for j >= 0 && bm.pat[j] == s[i+j] {
+			j--
+		}
+		if j < 0 {
+			pos = append(pos, i)
+			i += bm.suffix[0]
+			continue
+		}
+		bad := j - bm.last[s[i+j]]
+		if good := bm.suffix[j+1]; good > bad {
+			bad = good
+		}
+		i += bad
+	}
+	return pos
+}
+
+// Horspool is Boyer-Moore with only the bad character rule,
+// always keyed on the char aligned with the pattern end
+type Horspool struct {
+	pat   []byte
+	shift [256]int
+}
+
+func NewHorspool(pattern string) (*Horspool, error) {
+	if pattern == "" {
+		return nil, emptyPatternErr
+	}
+	h := &Horspool{pat: []byte(pattern)}
+	m := len(h.pat)
+	for i := range h.shift {
+		h.shift[i] = m
+	}
+	for i := 0; i < m-1; i++ {
+		h.shift[h.pat[i]] = m - 1 - i
+	}
+	return h, nil
+}
+
+func (h *Horspool) FindAll(s string) []int {
+	pos, m := []int{}, len(h.pat)
+	for i := 0; i <= len(s)-m; i += h.shift[s[i+m-1]] {
+		j := m - 1
+		for j >= 0 && h.pat[j] == s[i+j] {
+			j--
+		}
+		if j < 0 {
+			pos = append(pos, i)
+		}
+	}
+	return pos
+}
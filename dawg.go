@@ -0,0 +1,208 @@
+package algo
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+var unsortedKeysErr = fmt.Errorf("keys must be in ascending order")
+
+type dawgEdge struct {
+	c  byte
+	to *dawgNode
+}
+
+// count is the number of keys accepted from this node, used
+// to number keys by their position in the sorted key list
+type dawgNode struct {
+	id    int
+	final bool
+	edges []dawgEdge
+	count int
+}
+
+// nodes with equal signatures accept the same suffixes, the
+// final flag takes one byte and edges are a char followed by the
+// uvarint child id, so distinct nodes never share a signature
+func (n *dawgNode) signature() string {
+	sig := []byte{0}
+	if n.final {
+		sig[0] = 1
+	}
+	for _, e := range n.edges {
+		sig = append(sig, e.c)
+		sig = binary.AppendUvarint(sig, uint64(e.to.id))
+	}
+	return string(sig)
+}
+
+func (n *dawgNode) next(c byte) (*dawgNode, int) {
+	skipped := 0
+	for _, e := range n.edges {
+		if e.c == c {
+			return e.to, skipped
+		}
+		skipped += e.to.count
+	}
+	return nil, 0
+}
+
+func (n *dawgNode) countKeys() int {
+	if n.count >= 0 {
+		return n.count
+	}
+	n.count = 0
+	if n.final {
+		n.count = 1
+	}
+	for _, e := range n.edges {
+		n.count += e.to.countKeys()
+	}
+	return n.count
+}
+
+func (n *dawgNode) collect(path []byte, results []string) []string {
+	if n.final {
+		results = append(results, string(path))
+	}
+	for _, e := range n.edges {
+		results = e.to.collect(append(path, e.c), results)
+	}
+	return results
+}
+
+// DAWG is an immutable minimal acyclic word graph, both common
+// prefixes and common suffixes of the keys share nodes. Keys are
+// numbered by their position in sorted order, so it doubles as a
+// minimal perfect hash of the key set.
+type DAWG struct {
+	root  *dawgNode
+	nodes int
+}
+
+// build with Daciuk's incremental algorithm, the suffix of the
+// previous key not shared with the next one is final and can be
+// merged with equivalent nodes already registered
+func BuildDAWG(sortedKeys []string) (*DAWG, error) {
+	d := &DAWG{root: &dawgNode{count: -1}}
+	register := map[string]*dawgNode{}
+	path := []*dawgNode{d.root}
+
+	minimize := func(depth int) {
+		for i := len(path) - 1; i > depth; i-- {
+			child, parent := path[i], path[i-1]
+			sig := child.signature()
+			if same, ok := register[sig]; ok {
+				parent.edges[len(parent.edges)-1].to = same
+			} else {
+				d.nodes++
+				child.id = d.nodes
+				register[sig] = child
+			}
+		}
+		path = path[:depth+1]
+	}
+
+	prev := ""
+	for i, key := range sortedKeys {
+		if i > 0 && key <= prev {
+			if key == prev {
+				continue
+			}
+			return nil, unsortedKeysErr
+		}
+		cp := commonPrefixLen([]byte(prev), []byte(key))
+		minimize(cp)
+		n := path[cp]
+		for j := cp; j < len(key); j++ {
+			c := &dawgNode{count: -1}
+			n.edges = append(n.edges, dawgEdge{key[j], c})
+			path = append(path, c)
+			n = c
+		}
+		n.final = true
+		prev = key
+	}
+	minimize(0)
+	d.nodes++
+	d.root.countKeys()
+
+	return d, nil
+}
+
+// Freeze builds a DAWG holding the keys of the set
+func (s *TrieSet) Freeze() *DAWG {
+	d, _ := BuildDAWG(s.KeysWithPrefix(""))
+	return d
+}
+
+func (d *DAWG) Size() int { return d.root.count }
+
+func (d *DAWG) IsEmpty() bool { return d.root.count == 0 }
+
+// number of nodes in the graph
+func (d *DAWG) Nodes() int { return d.nodes }
+
+func (d *DAWG) get(s string) *dawgNode {
+	n := d.root
+	for i := 0; i < len(s) && n != nil; i++ {
+		n, _ = n.next(s[i])
+	}
+	return n
+}
+
+func (d *DAWG) Contains(key string) bool {
+	n := d.get(key)
+	return n != nil && n.final
+}
+
+func (d *DAWG) KeysWithPrefix(prefix string) []string {
+	n := d.get(prefix)
+	if n == nil {
+		return nil
+	}
+	return n.collect([]byte(prefix), []string{})
+}
+
+// Index returns the position of key in the sorted key list
+func (d *DAWG) Index(key string) (int, bool) {
+	n, idx := d.root, 0
+	for i := 0; i < len(key); i++ {
+		if n.final {
+			idx++
+		}
+		next, skipped := n.next(key[i])
+		if next == nil {
+			return 0, false
+		}
+		n, idx = next, idx+skipped
+	}
+	if !n.final {
+		return 0, false
+	}
+	return idx, true
+}
+
+// Key returns the key at position i of the sorted key list
+func (d *DAWG) Key(i int) (string, bool) {
+	if i < 0 || i >= d.root.count {
+		return "", false
+	}
+	n, key := d.root, []byte{}
+	for {
+		if n.final {
+			if i == 0 {
+				return string(key), true
+			}
+			i--
+		}
+		for _, e := range n.edges {
+			if i < e.to.count {
+				key = append(key, e.c)
+				n = e.to
+				break
+			}
+			i -= e.to.count
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_DAWG(t *testing.T) {
+	_, err := algo.BuildDAWG([]string{"b", "a"})
+	assert.Error(t, err, "keys must be in ascending order")
+
+	d, err := algo.BuildDAWG([]string{"tap", "taps", "top", "tops", "tops"})
+	assert.NilError(t, err)
+	assert.Equal(t, d.Size(), 4)
+	// t -> a|o -> p -> s shares the "p(s)" suffix
+	assert.Equal(t, d.Nodes(), 5)
+	assert.Assert(t, d.Contains("tops"))
+	assert.Assert(t, !d.Contains("to"))
+	assert.DeepEqual(t, d.KeysWithPrefix("to"), []string{"top", "tops"})
+	assert.Assert(t, d.KeysWithPrefix("x") == nil)
+
+	set := algo.TrieSet{}
+	for i := 0; i < 3000; i++ {
+		assert.NilError(t, set.Put(fmt.Sprintf("%x", rand.Intn(1<<20))))
+	}
+	assert.NilError(t, set.Put(""))
+	keys := set.KeysWithPrefix("")
+	d = set.Freeze()
+	assert.Equal(t, d.Size(), len(keys))
+	for i, k := range keys {
+		assert.Assert(t, d.Contains(k))
+		idx, ok := d.Index(k)
+		assert.Assert(t, ok && idx == i)
+		key, ok := d.Key(i)
+		assert.Assert(t, ok && key == k)
+	}
+	_, ok := d.Key(len(keys))
+	assert.Assert(t, !ok)
+	_, ok = d.Index("zz")
+	assert.Assert(t, !ok)
+	for _, p := range []string{"", "1", "a", "ff"} {
+		assert.DeepEqual(t, d.KeysWithPrefix(p), set.KeysWithPrefix(p))
+	}
+	assert.Assert(t, slices.IsSorted(d.KeysWithPrefix("")))
+
+	empty := (&algo.TrieSet{}).Freeze()
+	assert.Assert(t, empty.IsEmpty())
+	assert.Assert(t, !empty.Contains(""))
+}
+
+func TestAlgo_DAWGRandom(t *testing.T) {
+	// chars that could be mistaken for node signature syntax
+	rnd := rand.New(rand.NewSource(1))
+	for round := 0; round < 500; round++ {
+		keys := []string{}
+		for i := rnd.Intn(300); i > 0; i-- {
+			k := make([]byte, rnd.Intn(5))
+			for j := range k {
+				k[j] = "!,0123456789"[rnd.Intn(12)]
+			}
+			keys = append(keys, string(k))
+		}
+		slices.Sort(keys)
+		keys = slices.Compact(keys)
+
+		d, err := algo.BuildDAWG(keys)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, d.KeysWithPrefix(""), append([]string{}, keys...))
+		for i, k := range keys {
+			assert.Assert(t, d.Contains(k), k)
+			idx, ok := d.Index(k)
+			assert.Assert(t, ok && idx == i, k)
+			key, ok := d.Key(i)
+			assert.Assert(t, ok && key == k, k)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package algo
+
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+func (op DiffOp) String() string {
+	switch op {
+	case DiffInsert:
+		return "+"
+	case DiffDelete:
+		return "-"
+	}
+	return "="
+}
+
+// DiffHunk is a run of lines sharing the same operation,
+// inserted lines come from b, equal and deleted ones from a
+type DiffHunk struct {
+	Op    DiffOp
+	Lines []string
+}
+
+// single edit, ai and bi index the element in a and b
+type diffEdit struct {
+	op     DiffOp
+	ai, bi int
+}
+
+// Myers' O(ND) algorithm in linear space: the middle snake of a
+// shortest edit script is found by running the search forward
+// from the start and backward from the end until the two meet,
+// then both halves are diffed recursively. Only the furthest
+// reaching x per diagonal is kept, so memory is O(N+M).
+func myersDiff[T comparable](a, b []T) []diffEdit {
+	size := len(a) + len(b) + 3
+	d := &differ[T]{a: a, b: b, vf: make([]int, size), vb: make([]int, size), edits: []diffEdit{}}
+	d.diff(0, len(a), 0, len(b))
+	return d.edits
+}
+
+// vf[k] and vb[k] are the furthest x reached on diagonal k = x - y
+// from the start and from the end of the compared ranges
+type differ[T comparable] struct {
+	a, b   []T
+	vf, vb []int
+	edits  []diffEdit
+}
+
+func (d *differ[T]) diff(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.edits = append(d.edits, diffEdit{DiffEqual, a0, b0})
+		a0++
+		b0++
+	}
+	suffix := 0
+	for a1-suffix > a0 && b1-suffix > b0 && d.a[a1-suffix-1] == d.b[b1-suffix-1] {
+		suffix++
+	}
+	a1, b1 = a1-suffix, b1-suffix
+
+	switch {
+	case a0 == a1:
+		for j := b0; j < b1; j++ {
+			d.edits = append(d.edits, diffEdit{DiffInsert, a0, j})
+		}
+	case b0 == b1:
+		for i := a0; i < a1; i++ {
+			d.edits = append(d.edits, diffEdit{DiffDelete, i, b0})
+		}
+	default:
+		// both ranges are non empty without a common prefix or
+		// suffix, so at least two edits are needed and the split
+		// point lies strictly inside
+		x, y := d.bisect(a0, a1, b0, b1)
+		d.diff(a0, x, b0, y)
+		d.diff(x, a1, y, b1)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.edits = append(d.edits, diffEdit{DiffEqual, a1 + i, b1 + i})
+	}
+}
+
+// bisect returns the end of the forward snake where the forward
+// and backward searches first overlap, which lies on a shortest
+// edit script of the ranges
+func (d *differ[T]) bisect(a0, a1, b0, b1 int) (int, int) {
+	n, m := a1-a0, b1-b0
+	maxD := (n + m + 1) / 2
+	off, vlen := maxD, 2*maxD+2
+	vf, vb := d.vf[:vlen], d.vb[:vlen]
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[off+1], vb[off+1] = 0, 0
+	delta := n - m
+	// with odd delta the paths meet on a forward step, else backward
+	front := delta%2 != 0
+	// diagonals that ran off the grid are not extended further
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+
+	for e := 0; e < maxD; e++ {
+		for k := -e + kfStart; k <= e-kfEnd; k += 2 {
+			x := vf[off+k-1] + 1
+			if k == -e || (k != e && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			}
+			y := x - k
+			for x < n && y < m && d.a[a0+x] == d.b[b0+y] {
+				x++
+				y++
+			}
+			vf[off+k] = x
+			if x > n {
+				kfEnd += 2
+			} else if y > m {
+				kfStart += 2
+			} else if front {
+				if kb := off + delta - k; kb >= 0 && kb < vlen && vb[kb] != -1 && x >= n-vb[kb] {
+					return a0 + x, b0 + y
+				}
+			}
+		}
+		for k := -e + kbStart; k <= e-kbEnd; k += 2 {
+			x := vb[off+k-1] + 1
+			if k == -e || (k != e && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			}
+			y := x - k
+			for x < n && y < m && d.a[a1-x-1] == d.b[b1-y-1] {
+				x++
+				y++
+			}
+			vb[off+k] = x
+			if x > n {
+				kbEnd += 2
+			} else if y > m {
+				kbStart += 2
+			} else if !front {
+				if kf := off + delta - k; kf >= 0 && kf < vlen && vf[kf] != -1 && vf[kf] >= n-x {
+					xf := vf[kf]
+					return a0 + xf, b0 + xf - (kf - off)
+				}
+			}
+		}
+	}
+	// not reached, the searches always meet within maxD rounds
+	return a1, b0
+}
+
+// Diff returns the shortest edit script turning lines a into
+// lines b, consecutive edits of the same kind form one hunk
+func Diff(a, b []string) []DiffHunk {
+	hunks := []DiffHunk{}
+	for _, e := range myersDiff(a, b) {
+		line := a[e.ai:]
+		if e.op == DiffInsert {
+			line = b[e.bi:]
+		}
+		if len(hunks) == 0 || hunks[len(hunks)-1].Op != e.op {
+			hunks = append(hunks, DiffHunk{Op: e.op})
+		}
+		h := &hunks[len(hunks)-1]
+		h.Lines = append(h.Lines, line[0])
+	}
+	return hunks
+}
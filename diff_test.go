@@ -0,0 +1,100 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_Diff(t *testing.T) {
+	a := strings.Split("a b c a b b a", " ")
+	b := strings.Split("c b a b a c", " ")
+	hunks := algo.Diff(a, b)
+	assert.DeepEqual(t, hunks, []algo.DiffHunk{
+		{Op: algo.DiffDelete, Lines: []string{"a"}},
+		{Op: algo.DiffInsert, Lines: []string{"c"}},
+		{Op: algo.DiffEqual, Lines: []string{"b"}},
+		{Op: algo.DiffDelete, Lines: []string{"c"}},
+		{Op: algo.DiffEqual, Lines: []string{"a", "b"}},
+		{Op: algo.DiffDelete, Lines: []string{"b"}},
+		{Op: algo.DiffEqual, Lines: []string{"a"}},
+		{Op: algo.DiffInsert, Lines: []string{"c"}},
+	})
+	assert.Equal(t, algo.DiffInsert.String(), "+")
+	assert.DeepEqual(t, algo.Diff(nil, nil), []algo.DiffHunk{})
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 200; i++ {
+		a := strings.Split(randText(r, "xyz", r.Intn(40)), "")
+		b := strings.Split(randText(r, "xyz", r.Intn(40)), "")
+		olds, news, edits := []string{}, []string{}, 0
+		for _, h := range algo.Diff(a, b) {
+			if h.Op != algo.DiffInsert {
+				olds = append(olds, h.Lines...)
+			}
+			if h.Op != algo.DiffDelete {
+				news = append(news, h.Lines...)
+			}
+			if h.Op != algo.DiffEqual {
+				edits += len(h.Lines)
+			}
+		}
+		assert.DeepEqual(t, olds, a)
+		assert.DeepEqual(t, news, b)
+		lcs := naiveLCSLen([]rune(strings.Join(a, "")), []rune(strings.Join(b, "")))
+		assert.Equal(t, edits, len(a)+len(b)-2*lcs)
+	}
+}
+
+func TestAlgo_DiffLarge(t *testing.T) {
+	n := 4000
+	a, b := make([]string, n), make([]string, n)
+	for i := range a {
+		a[i], b[i] = fmt.Sprint("a", i), fmt.Sprint("b", i)
+	}
+
+	// disjoint inputs take the most edits, memory must stay linear
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	hunks := algo.Diff(a, b)
+	runtime.ReadMemStats(&after)
+	assert.DeepEqual(t, hunks, []algo.DiffHunk{
+		{Op: algo.DiffDelete, Lines: a},
+		{Op: algo.DiffInsert, Lines: b},
+	})
+	assert.Assert(t, after.TotalAlloc-before.TotalAlloc < 4<<20, after.TotalAlloc-before.TotalAlloc)
+
+	// scattered edits between mostly equal inputs
+	r := rand.New(rand.NewSource(1))
+	b = append([]string{}, a...)
+	edits := 0
+	for i := 0; i < 200; i++ {
+		j := r.Intn(len(b))
+		if r.Intn(2) == 0 {
+			b = append(b[:j], b[j+1:]...)
+		} else {
+			b = append(b[:j], append([]string{fmt.Sprint("new", i)}, b[j:]...)...)
+		}
+		edits++
+	}
+	olds, news, got := []string{}, []string{}, 0
+	for _, h := range algo.Diff(a, b) {
+		if h.Op != algo.DiffInsert {
+			olds = append(olds, h.Lines...)
+		}
+		if h.Op != algo.DiffDelete {
+			news = append(news, h.Lines...)
+		}
+		if h.Op != algo.DiffEqual {
+			got += len(h.Lines)
+		}
+	}
+	assert.DeepEqual(t, olds, a)
+	assert.DeepEqual(t, news, b)
+	assert.Assert(t, got <= edits, got)
+}
@@ -0,0 +1,102 @@
+package algo
+
+// Levenshtein returns the minimum number of rune insertions,
+// deletions and substitutions turning a into b, keeping only
+// one dp row of the shorter string
+func Levenshtein(a, b string) int {
+	s, t := torune(a), torune(b)
+	if len(s) < len(t) {
+		s, t = t, s
+	}
+	row := make([]int, len(t)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			v := diag
+			if s[i-1] != t[j-1] {
+				v++
+			}
+			if row[j]+1 < v {
+				v = row[j] + 1
+			}
+			if row[j-1]+1 < v {
+				v = row[j-1] + 1
+			}
+			diag, row[j] = row[j], v
+		}
+	}
+	return row[len(t)]
+}
+
+// Damerau returns the Damerau-Levenshtein distance, where swapping
+// two adjacent runes also counts as one edit. Unlike the optimal
+// string alignment variant, substrings may be edited again after
+// a transposition.
+func Damerau(a, b string) int {
+	s, t := torune(a), torune(b)
+	n, m := len(s), len(t)
+	inf := n + m
+
+	// d[i+1][j+1] is the distance of s[:i] and t[:j], the extra
+	// border row and column hold inf to bound transpositions
+	d := make([][]int, n+2)
+	for i := range d {
+		d[i] = make([]int, m+2)
+		d[i][0] = inf
+	}
+	for j := range d[0] {
+		d[0][j] = inf
+	}
+	for i := 0; i <= n; i++ {
+		d[i+1][1] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[1][j+1] = j
+	}
+
+	// last row where each rune was seen in s
+	last := map[rune]int{}
+	for i := 1; i <= n; i++ {
+		db := 0 // last column in this row where s[i-1] matched
+		for j := 1; j <= m; j++ {
+			k, l := last[t[j-1]], db
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost, db = 0, j
+			}
+			v := d[i][j] + cost
+			if d[i+1][j]+1 < v {
+				v = d[i+1][j] + 1
+			}
+			if d[i][j+1]+1 < v {
+				v = d[i][j+1] + 1
+			}
+			if tr := d[k][l] + (i - k - 1) + 1 + (j - l - 1); tr < v {
+				v = tr
+			}
+			d[i+1][j+1] = v
+		}
+		last[s[i-1]] = i
+	}
+	return d[n+1][m+1]
+}
+
+// LongestCommonSubsequence returns the longest rune sequence
+// appearing in order in both a and b. When several exist, the one
+// returned is made of the equal hunks Diff produces for the runes
+// of a and b: the common prefix and suffix are always part of it
+// and ties in between are decided by the middle snake split, so
+// "ABCBDAB" and "BDCABA" give "BCBA" rather than "BCAB" or "BDAB".
+func LongestCommonSubsequence(a, b string) string {
+	s, lcs := torune(a), []rune{}
+	for _, e := range myersDiff(s, torune(b)) {
+		if e.op == DiffEqual {
+			lcs = append(lcs, s[e.ai])
+		}
+	}
+	return string(lcs)
+}
@@ -0,0 +1,63 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func naiveLCSLen(a, b []rune) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else if dp[i-1][j] > dp[i][j-1] {
+				dp[i][j] = dp[i-1][j]
+			} else {
+				dp[i][j] = dp[i][j-1]
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
+
+func isSubsequence(sub, s []rune) bool {
+	i := 0
+	for _, c := range s {
+		if i < len(sub) && sub[i] == c {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+func TestAlgo_EditDistance(t *testing.T) {
+	assert.Equal(t, algo.Levenshtein("kitten", "sitting"), 3)
+	assert.Equal(t, algo.Levenshtein("", "abc"), 3)
+	assert.Equal(t, algo.Levenshtein("你好", "你们好"), 1)
+	assert.Equal(t, algo.Damerau("ca", "ac"), 1)
+	assert.Equal(t, algo.Damerau("ca", "abc"), 2)
+	assert.Equal(t, algo.Damerau("abcdef", "abdcef"), 1)
+	assert.Equal(t, algo.Damerau("", ""), 0)
+	assert.Equal(t, algo.LongestCommonSubsequence("ABCBDAB", "BDCABA"), "BCBA")
+	assert.Equal(t, algo.LongestCommonSubsequence("abc", ""), "")
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 300; i++ {
+		a, b := randText(r, "abc", r.Intn(30)), randText(r, "abc", r.Intn(30))
+		lev, dam := algo.Levenshtein(a, b), algo.Damerau(a, b)
+		assert.Assert(t, dam <= lev)
+		assert.Equal(t, lev, algo.Levenshtein(b, a))
+		assert.Equal(t, dam, algo.Damerau(b, a))
+
+		lcs := []rune(algo.LongestCommonSubsequence(a, b))
+		assert.Equal(t, len(lcs), naiveLCSLen([]rune(a), []rune(b)))
+		assert.Assert(t, isSubsequence(lcs, []rune(a)) && isSubsequence(lcs, []rune(b)))
+	}
+}
@@ -0,0 +1,283 @@
+package algo
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	defaultSortMemory   = 64 << 20
+	defaultSortOpenRuns = 64
+	// in memory cost of a record besides its bytes, its slice header
+	recordOverhead = 24
+)
+
+var truncatedRecordErr = fmt.Errorf("truncated fixed size record")
+
+// ExternalSortOptions configures ExternalSort, zero values select
+// the defaults
+type ExternalSortOptions struct {
+	// bytes of records held in memory at once, 64MB by default
+	MemoryBudget int
+	// size of binary records, 0 means newline delimited records
+	RecordSize int
+	// directory for sorted runs, os.TempDir by default
+	TempDir string
+	// runs merged at once, more runs are merged in several passes
+	// to bound open files, 64 by default
+	MaxOpenRuns int
+	// record order, bytes.Compare by default
+	Compare func(a, b []byte) int
+}
+
+type extSorter struct {
+	opts ExternalSortOptions
+	runs []string
+}
+
+// ExternalSort sorts the records of r into w using a bounded
+// amount of memory: chunks of records that fit the memory budget
+// are sorted with Qsort3way and spilled to temporary files as
+// sorted runs, then the runs are k-way merged with a heap. Input
+// that fits the budget is sorted in memory without temporary
+// files. Newline delimited records are written back with a
+// trailing newline, even when the last input line has none. The
+// temporary files are removed before returning, on errors too.
+func ExternalSort(r io.Reader, w io.Writer, opts ExternalSortOptions) (err error) {
+	if opts.RecordSize < 0 {
+		return fmt.Errorf("negative record size %d", opts.RecordSize)
+	}
+	if opts.MemoryBudget <= 0 {
+		opts.MemoryBudget = defaultSortMemory
+	}
+	if opts.MaxOpenRuns < 2 {
+		opts.MaxOpenRuns = defaultSortOpenRuns
+	}
+	if opts.Compare == nil {
+		opts.Compare = bytes.Compare
+	}
+	e := &extSorter{opts: opts}
+	defer func() {
+		for _, name := range e.runs {
+			if rerr := os.Remove(name); rerr != nil && err == nil {
+				err = rerr
+			}
+		}
+	}()
+
+	br := bufio.NewReader(r)
+	for {
+		recs, eof, err := e.readChunk(br)
+		if err != nil {
+			return err
+		}
+		Qsort3wayFunc(recs, opts.Compare)
+		if eof && len(e.runs) == 0 {
+			return e.writeRecords(w, recs)
+		}
+		if len(recs) > 0 {
+			if err := e.spill(recs); err != nil {
+				return err
+			}
+		}
+		if eof {
+			break
+		}
+	}
+
+	runs := append([]string{}, e.runs...)
+	for len(runs) > opts.MaxOpenRuns {
+		merged, err := e.mergeToRun(runs[:opts.MaxOpenRuns])
+		if err != nil {
+			return err
+		}
+		if err := e.removeRuns(runs[:opts.MaxOpenRuns]); err != nil {
+			return err
+		}
+		runs = append(runs[opts.MaxOpenRuns:], merged)
+	}
+	return e.merge(runs, w)
+}
+
+// read a record without its newline, io.EOF only at a record boundary
+func (e *extSorter) readRecord(br *bufio.Reader) ([]byte, error) {
+	if e.opts.RecordSize > 0 {
+		rec := make([]byte, e.opts.RecordSize)
+		_, err := io.ReadFull(br, rec)
+		if err == io.ErrUnexpectedEOF {
+			return nil, truncatedRecordErr
+		}
+		return rec, err
+	}
+	rec, err := br.ReadBytes('\n')
+	if err == io.EOF && len(rec) > 0 {
+		return rec, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec[:len(rec)-1], nil
+}
+
+func (e *extSorter) writeRecord(bw *bufio.Writer, rec []byte) error {
+	if _, err := bw.Write(rec); err != nil {
+		return err
+	}
+	if e.opts.RecordSize == 0 {
+		return bw.WriteByte('\n')
+	}
+	return nil
+}
+
+func (e *extSorter) writeRecords(w io.Writer, recs [][]byte) error {
+	bw := bufio.NewWriter(w)
+	for _, rec := range recs {
+		if err := e.writeRecord(bw, rec); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// read records until the memory budget is used up, a chunk holds
+// at least one record however large
+func (e *extSorter) readChunk(br *bufio.Reader) ([][]byte, bool, error) {
+	recs, used := [][]byte{}, 0
+	for used < e.opts.MemoryBudget {
+		rec, err := e.readRecord(br)
+		if err == io.EOF {
+			return recs, true, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		recs = append(recs, rec)
+		used += len(rec) + recordOverhead
+	}
+	return recs, false, nil
+}
+
+// create a temporary file for a run, registered for removal first
+// so it is cleaned up whatever happens next
+func (e *extSorter) createRun() (*os.File, error) {
+	f, err := os.CreateTemp(e.opts.TempDir, "extsort-*.run")
+	if err != nil {
+		return nil, err
+	}
+	e.runs = append(e.runs, f.Name())
+	return f, nil
+}
+
+func (e *extSorter) spill(recs [][]byte) error {
+	f, err := e.createRun()
+	if err != nil {
+		return err
+	}
+	if err := e.writeRecords(f, recs); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (e *extSorter) mergeToRun(runs []string) (string, error) {
+	f, err := e.createRun()
+	if err != nil {
+		return "", err
+	}
+	if err := e.merge(runs, f); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// current smallest record of a run
+type runHead struct {
+	rec []byte
+	br  *bufio.Reader
+}
+
+type runHeap struct {
+	heads []runHead
+	cmp   func(a, b []byte) int
+}
+
+func (h *runHeap) Len() int { return len(h.heads) }
+
+func (h *runHeap) Less(i, j int) bool { return h.cmp(h.heads[i].rec, h.heads[j].rec) < 0 }
+
+func (h *runHeap) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *runHeap) Push(x any) { h.heads = append(h.heads, x.(runHead)) }
+
+func (h *runHeap) Pop() any {
+	it := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return it
+}
+
+// remove runs already merged into another, so a multi pass merge
+// needs little more temporary space than the input
+func (e *extSorter) removeRuns(names []string) (err error) {
+	removed := map[string]bool{}
+	for _, name := range names {
+		if err = os.Remove(name); err != nil {
+			break
+		}
+		removed[name] = true
+	}
+	kept := e.runs[:0]
+	for _, name := range e.runs {
+		if !removed[name] {
+			kept = append(kept, name)
+		}
+	}
+	e.runs = kept
+	return err
+}
+
+// k-way merge of sorted run files into w
+func (e *extSorter) merge(runs []string, w io.Writer) error {
+	h := &runHeap{cmp: e.opts.Compare}
+	for _, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		br := bufio.NewReader(f)
+		rec, err := e.readRecord(br)
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		h.heads = append(h.heads, runHead{rec, br})
+	}
+	heap.Init(h)
+
+	bw := bufio.NewWriter(w)
+	for h.Len() > 0 {
+		top := &h.heads[0]
+		if err := e.writeRecord(bw, top.rec); err != nil {
+			return err
+		}
+		rec, err := e.readRecord(top.br)
+		if err == io.EOF {
+			heap.Pop(h)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		top.rec = rec
+		heap.Fix(h, 0)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,167 @@
+package algo_test
+
+import (
+	"algo"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"gotest.tools/v3/assert"
+)
+
+func assertEmptyDir(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+}
+
+func TestAlgo_ExternalSort(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	lines := []string{""}
+	for i := 0; i < 5000; i++ {
+		lines = append(lines, randText(rnd, "abcdef", rnd.Intn(12)))
+	}
+	input := strings.Join(lines, "\n") // no trailing newline
+	expected := append([]string{}, lines...)
+	sort.Strings(expected)
+	want := strings.Join(expected, "\n") + "\n"
+
+	for _, opts := range []algo.ExternalSortOptions{
+		{},                                      // in memory
+		{MemoryBudget: 4096},                    // single merge pass
+		{MemoryBudget: 1024, MaxOpenRuns: 3},    // several passes
+		{MemoryBudget: 1, MaxOpenRuns: 1 << 10}, // one record per run
+	} {
+		opts.TempDir = t.TempDir()
+		out := &bytes.Buffer{}
+		assert.NilError(t, algo.ExternalSort(strings.NewReader(input), out, opts))
+		assert.Equal(t, out.String(), want)
+		assertEmptyDir(t, opts.TempDir)
+	}
+
+	out := &bytes.Buffer{}
+	assert.NilError(t, algo.ExternalSort(strings.NewReader(""), out, algo.ExternalSortOptions{}))
+	assert.Equal(t, out.String(), "")
+}
+
+func TestAlgo_ExternalSortTempSpace(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	lines := []string{}
+	for i := 0; i < 3000; i++ {
+		lines = append(lines, randText(rnd, "abcdef", 1+rnd.Intn(12)))
+	}
+	dir := t.TempDir()
+	// runs left in the temporary directory, sampled while comparing
+	ncmp, left := 0, 0
+	opts := algo.ExternalSortOptions{
+		MemoryBudget: 1024,
+		MaxOpenRuns:  4,
+		TempDir:      dir,
+		Compare: func(a, b []byte) int {
+			if ncmp++; ncmp%50 == 0 {
+				entries, err := os.ReadDir(dir)
+				assert.NilError(t, err)
+				left = len(entries)
+			}
+			return bytes.Compare(a, b)
+		},
+	}
+	out := &bytes.Buffer{}
+	assert.NilError(t, algo.ExternalSort(strings.NewReader(strings.Join(lines, "\n")), out, opts))
+	sort.Strings(lines)
+	assert.Equal(t, out.String(), strings.Join(lines, "\n")+"\n")
+	// merged runs are gone by the final merge pass
+	assert.Assert(t, left > 0 && left <= opts.MaxOpenRuns, left)
+	assertEmptyDir(t, dir)
+}
+
+func TestAlgo_ExternalSortBinary(t *testing.T) {
+	// 8 byte records keyed by a little endian uint32
+	keys := rand.Perm(3000)
+	input := []byte{}
+	for i, k := range keys {
+		input = binary.LittleEndian.AppendUint32(input, uint32(k))
+		input = binary.LittleEndian.AppendUint32(input, uint32(i))
+	}
+	opts := algo.ExternalSortOptions{
+		MemoryBudget: 2000,
+		RecordSize:   8,
+		TempDir:      t.TempDir(),
+		Compare: func(a, b []byte) int {
+			return int(binary.LittleEndian.Uint32(a)) - int(binary.LittleEndian.Uint32(b))
+		},
+	}
+	out := &bytes.Buffer{}
+	assert.NilError(t, algo.ExternalSort(bytes.NewReader(input), out, opts))
+	assert.Equal(t, out.Len(), len(input))
+	for i := 0; i < len(keys); i++ {
+		rec := out.Bytes()[8*i:]
+		k, at := binary.LittleEndian.Uint32(rec), binary.LittleEndian.Uint32(rec[4:])
+		assert.Equal(t, int(k), i)
+		assert.Equal(t, keys[at], i)
+	}
+	assertEmptyDir(t, opts.TempDir)
+
+	err := algo.ExternalSort(bytes.NewReader(input[:len(input)-3]), io.Discard, opts)
+	assert.ErrorContains(t, err, "truncated")
+	assertEmptyDir(t, opts.TempDir)
+}
+
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n < len(p) {
+		return 0, errors.New("disk full")
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestAlgo_ExternalSortErrors(t *testing.T) {
+	input := strings.Repeat("zyx\nwvu\ntsr\n", 1000)
+	opts := algo.ExternalSortOptions{MemoryBudget: 512, MaxOpenRuns: 4, TempDir: t.TempDir()}
+
+	// the input fails after some runs have been spilled
+	r := io.MultiReader(strings.NewReader(input), iotest.ErrReader(errors.New("read failed")))
+	err := algo.ExternalSort(r, io.Discard, opts)
+	assert.ErrorContains(t, err, "read failed")
+	assertEmptyDir(t, opts.TempDir)
+
+	err = algo.ExternalSort(strings.NewReader(input), &failingWriter{n: 5000}, opts)
+	assert.ErrorContains(t, err, "disk full")
+	assertEmptyDir(t, opts.TempDir)
+
+	opts.TempDir = opts.TempDir + "/missing"
+	err = algo.ExternalSort(strings.NewReader(input), io.Discard, opts)
+	assert.Assert(t, errors.Is(err, os.ErrNotExist), err)
+}
+
+func BenchmarkExternalSort(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	lines := []string{}
+	for i := 0; i < 100000; i++ {
+		lines = append(lines, randText(rnd, "abcdefghijklmnopqrstuvwxyz", 20+rnd.Intn(60)))
+	}
+	input := strings.Join(lines, "\n")
+	for _, budget := range []int{0, 1 << 20} {
+		name := "memory"
+		if budget > 0 {
+			name = "spill"
+		}
+		b.Run(name, func(b *testing.B) {
+			opts := algo.ExternalSortOptions{MemoryBudget: budget, TempDir: b.TempDir()}
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				assert.NilError(b, algo.ExternalSort(strings.NewReader(input), io.Discard, opts))
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ module algo
 
 go 1.20
 
-require gotest.tools/v3 v3.5.1
-
 require (
-	github.com/google/go-cmp v0.5.9 // indirect
-	golang.org/x/exp v0.0.0-20231110203233-9a3e6036ecaa // indirect
+	golang.org/x/exp v0.0.0-20231110203233-9a3e6036ecaa
+	gotest.tools/v3 v3.5.1
 )
+
+require github.com/google/go-cmp v0.5.9 // indirect
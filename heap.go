@@ -0,0 +1,105 @@
+package algo
+
+import "golang.org/x/exp/constraints"
+
+// Heap is a d-ary heap, the root holds the smallest element by
+// cmp. Wider heaps are shallower, so pushes compare less while
+// pops compare more, which suits push heavy uses like Dijkstra.
+type Heap[T any] struct {
+	items []T
+	cmp   func(a, b T) int
+	d     int
+}
+
+// NewHeap returns a binary heap ordered by cmp
+func NewHeap[T any](cmp func(a, b T) int) *Heap[T] {
+	return NewDaryHeap(2, cmp)
+}
+
+func NewMinHeap[T constraints.Ordered]() *Heap[T] {
+	return NewHeap(cmpOrdered[T])
+}
+
+func NewMaxHeap[T constraints.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) int { return cmpOrdered(b, a) })
+}
+
+// NewDaryHeap returns a heap where every node has d children,
+// it panics if d is less than 2
+func NewDaryHeap[T any](d int, cmp func(a, b T) int) *Heap[T] {
+	if d < 2 {
+		panic("algo: heap arity less than 2")
+	}
+	return &Heap[T]{cmp: cmp, d: d}
+}
+
+func (h *Heap[T]) Len() int { return len(h.items) }
+
+func (h *Heap[T]) IsEmpty() bool { return len(h.items) == 0 }
+
+func (h *Heap[T]) Check() bool {
+	for i := 1; i < len(h.items); i++ {
+		if h.cmp(h.items[(i-1)/h.d], h.items[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Heap[T]) Push(x T) {
+	h.items = append(h.items, x)
+	h.up(len(h.items) - 1)
+}
+
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+func (h *Heap[T]) Pop() (T, bool) {
+	top, ok := h.Peek()
+	if !ok {
+		return top, false
+	}
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.down(0)
+	return top, true
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / h.d
+		if h.cmp(h.items[p], h.items[i]) <= 0 {
+			return
+		}
+		h.items[p], h.items[i] = h.items[i], h.items[p]
+		i = p
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	for {
+		first := h.d*i + 1
+		if first >= len(h.items) {
+			return
+		}
+		c := first
+		for j := first + 1; j < first+h.d && j < len(h.items); j++ {
+			if h.cmp(h.items[j], h.items[c]) < 0 {
+				c = j
+			}
+		}
+		if h.cmp(h.items[i], h.items[c]) <= 0 {
+			return
+		}
+		h.items[i], h.items[c] = h.items[c], h.items[i]
+		i = c
+	}
+}
@@ -0,0 +1,99 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// sorted slice model of a min priority queue
+type pqModel []int
+
+func (m *pqModel) push(x int) {
+	i := sort.SearchInts(*m, x)
+	*m = append(*m, 0)
+	copy((*m)[i+1:], (*m)[i:])
+	(*m)[i] = x
+}
+
+func (m *pqModel) pop() int {
+	x := (*m)[0]
+	*m = (*m)[1:]
+	return x
+}
+
+type intHeap interface {
+	Push(int)
+	Pop() (int, bool)
+	Peek() (int, bool)
+	Len() int
+	Check() bool
+}
+
+func testHeap(t *testing.T, h intHeap) {
+	t.Helper()
+	model := pqModel{}
+	for i := 0; i < 10000; i++ {
+		if rand.Intn(3) > 0 {
+			x := rand.Intn(1000)
+			h.Push(x)
+			model.push(x)
+		} else if len(model) > 0 {
+			x, ok := h.Pop()
+			assert.Assert(t, ok)
+			assert.Equal(t, x, model.pop())
+		}
+		assert.Equal(t, h.Len(), len(model))
+		assert.Assert(t, h.Check(), "heap violated")
+	}
+	for len(model) > 0 {
+		x, ok := h.Peek()
+		assert.Assert(t, ok)
+		assert.Equal(t, x, model[0])
+		x, _ = h.Pop()
+		assert.Equal(t, x, model.pop())
+	}
+	_, ok := h.Pop()
+	assert.Assert(t, !ok)
+}
+
+func TestAlgo_Heap(t *testing.T) {
+	testHeap(t, algo.NewMinHeap[int]())
+	for _, d := range []int{2, 3, 4, 8} {
+		testHeap(t, algo.NewDaryHeap(d, func(a, b int) int { return a - b }))
+	}
+
+	h := algo.NewMaxHeap[string]()
+	for _, s := range []string{"b", "d", "a", "c"} {
+		h.Push(s)
+	}
+	for _, s := range []string{"d", "c", "b", "a"} {
+		x, _ := h.Pop()
+		assert.Equal(t, x, s)
+	}
+	assert.Assert(t, h.IsEmpty())
+
+	people := algo.NewHeap(func(a, b person) int { return a.age - b.age })
+	for _, p := range []person{{"x", 30}, {"y", 20}, {"z", 40}} {
+		people.Push(p)
+	}
+	p, _ := people.Pop()
+	assert.Equal(t, p.name, "y")
+}
+
+func BenchmarkHeap(b *testing.B) {
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			h := algo.NewDaryHeap(d, func(a, b int) int { return a - b })
+			for i := 0; i < b.N; i++ {
+				h.Push(rand.Int())
+				h.Push(rand.Int())
+				h.Pop()
+			}
+		})
+	}
+}
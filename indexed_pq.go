@@ -0,0 +1,150 @@
+package algo
+
+// IndexedPQ is a binary min heap of keys associated with indices
+// in [0, n), the key of an index can be found and changed in
+// place, as Dijkstra and Prim need. Methods panic on an index out
+// of range, and on a missing or duplicate index.
+type IndexedPQ[T any] struct {
+	cmp  func(a, b T) int
+	pq   []int // heap position to index
+	qp   []int // index to heap position, -1 when absent
+	keys []T
+}
+
+func NewIndexedPQ[T any](n int, cmp func(a, b T) int) *IndexedPQ[T] {
+	q := &IndexedPQ[T]{cmp: cmp, qp: make([]int, n), keys: make([]T, n)}
+	for i := range q.qp {
+		q.qp[i] = -1
+	}
+	return q
+}
+
+func (q *IndexedPQ[T]) Len() int { return len(q.pq) }
+
+func (q *IndexedPQ[T]) IsEmpty() bool { return len(q.pq) == 0 }
+
+func (q *IndexedPQ[T]) Contains(i int) bool {
+	return i >= 0 && i < len(q.qp) && q.qp[i] >= 0
+}
+
+func (q *IndexedPQ[T]) Check() bool {
+	for p, i := range q.pq {
+		if q.qp[i] != p {
+			return false
+		}
+		if p > 0 && q.less(p, (p-1)/2) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *IndexedPQ[T]) mustContain(i int, want bool) {
+	if i < 0 || i >= len(q.qp) {
+		panic("algo: priority queue index out of range")
+	}
+	if q.Contains(i) != want {
+		if want {
+			panic("algo: index not in priority queue")
+		}
+		panic("algo: index already in priority queue")
+	}
+}
+
+func (q *IndexedPQ[T]) Insert(i int, key T) {
+	q.mustContain(i, false)
+	q.qp[i] = len(q.pq)
+	q.pq = append(q.pq, i)
+	q.keys[i] = key
+	q.up(len(q.pq) - 1)
+}
+
+func (q *IndexedPQ[T]) Key(i int) (T, bool) {
+	if !q.Contains(i) {
+		var zero T
+		return zero, false
+	}
+	return q.keys[i], true
+}
+
+// Peek returns the index with the smallest key
+func (q *IndexedPQ[T]) Peek() (int, T, bool) {
+	if len(q.pq) == 0 {
+		var zero T
+		return -1, zero, false
+	}
+	return q.pq[0], q.keys[q.pq[0]], true
+}
+
+func (q *IndexedPQ[T]) Pop() (int, T, bool) {
+	i, key, ok := q.Peek()
+	if ok {
+		q.Delete(i)
+	}
+	return i, key, ok
+}
+
+// DecreaseKey lowers the key of index i, it panics if key is
+// greater than the current one
+func (q *IndexedPQ[T]) DecreaseKey(i int, key T) {
+	q.mustContain(i, true)
+	if q.cmp(key, q.keys[i]) > 0 {
+		panic("algo: key increased by DecreaseKey")
+	}
+	q.keys[i] = key
+	q.up(q.qp[i])
+}
+
+func (q *IndexedPQ[T]) ChangeKey(i int, key T) {
+	q.mustContain(i, true)
+	q.keys[i] = key
+	q.up(q.qp[i])
+	q.down(q.qp[i])
+}
+
+func (q *IndexedPQ[T]) Delete(i int) {
+	q.mustContain(i, true)
+	p, last := q.qp[i], len(q.pq)-1
+	q.swap(p, last)
+	q.pq = q.pq[:last]
+	q.qp[i] = -1
+	var zero T
+	q.keys[i] = zero
+	if p < last {
+		q.up(p)
+		q.down(p)
+	}
+}
+
+func (q *IndexedPQ[T]) less(a, b int) bool {
+	return q.cmp(q.keys[q.pq[a]], q.keys[q.pq[b]]) < 0
+}
+
+func (q *IndexedPQ[T]) swap(a, b int) {
+	q.pq[a], q.pq[b] = q.pq[b], q.pq[a]
+	q.qp[q.pq[a]], q.qp[q.pq[b]] = a, b
+}
+
+func (q *IndexedPQ[T]) up(p int) {
+	for p > 0 && q.less(p, (p-1)/2) {
+		q.swap(p, (p-1)/2)
+		p = (p - 1) / 2
+	}
+}
+
+func (q *IndexedPQ[T]) down(p int) {
+	for {
+		c := 2*p + 1
+		if c >= len(q.pq) {
+			return
+		}
+		if c+1 < len(q.pq) && q.less(c+1, c) {
+			c++
+		}
+		if !q.less(c, p) {
+			return
+		}
+		q.swap(p, c)
+		p = c
+	}
+}
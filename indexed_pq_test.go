@@ -0,0 +1,83 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_IndexedPQ(t *testing.T) {
+	n := 500
+	q := algo.NewIndexedPQ(n, func(a, b int) int { return a - b })
+	keys := map[int]int{} // model, smallest key is found by scanning
+
+	smallest := func() int {
+		min := -1
+		for i, k := range keys {
+			if min < 0 || k < keys[min] || (k == keys[min] && i < min) {
+				min = i
+			}
+		}
+		return min
+	}
+
+	for step := 0; step < 20000; step++ {
+		i := rand.Intn(n)
+		_, present := keys[i]
+		switch op := rand.Intn(5); {
+		case !present:
+			k := rand.Intn(10000)
+			q.Insert(i, k)
+			keys[i] = k
+		case op == 0:
+			k := rand.Intn(keys[i] + 1)
+			q.DecreaseKey(i, k)
+			keys[i] = k
+		case op == 1:
+			k := rand.Intn(10000)
+			q.ChangeKey(i, k)
+			keys[i] = k
+		case op == 2:
+			q.Delete(i)
+			delete(keys, i)
+		default:
+			m := smallest()
+			j, k, ok := q.Pop()
+			assert.Assert(t, ok)
+			// ties may pop any of the equal indices
+			assert.Equal(t, k, keys[m])
+			assert.Equal(t, k, keys[j])
+			delete(keys, j)
+		}
+		assert.Equal(t, q.Len(), len(keys))
+		assert.Assert(t, q.Check(), "heap violated")
+		k, ok := q.Key(i)
+		assert.Equal(t, ok, q.Contains(i))
+		if ok {
+			assert.Equal(t, k, keys[i])
+		}
+	}
+
+	assert.Assert(t, !q.Contains(-1) && !q.Contains(n))
+	for !q.IsEmpty() {
+		j, k, _ := q.Pop()
+		assert.Equal(t, k, keys[smallest()])
+		delete(keys, j)
+	}
+	_, _, ok := q.Peek()
+	assert.Assert(t, !ok)
+
+	q.Insert(3, 7)
+	assert.Assert(t, panics(func() { q.Insert(3, 1) }))
+	assert.Assert(t, panics(func() { q.DecreaseKey(3, 8) }))
+	assert.Assert(t, panics(func() { q.Delete(4) }))
+	assert.Assert(t, panics(func() { q.ChangeKey(n, 1) }))
+}
+
+func panics(fn func()) (p bool) {
+	defer func() { p = recover() != nil }()
+	fn()
+	return false
+}
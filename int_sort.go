@@ -0,0 +1,129 @@
+package algo
+
+import (
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// RadixSort is an LSD radix sort on 8 bit digits, signed keys are
+// ordered by flipping their sign bit. Passes over a digit that is
+// the same for every key are skipped.
+func RadixSort[T constraints.Integer](s []T) {
+	radixSort(s, 8)
+}
+
+// RadixSort16 takes half the passes of RadixSort with 16 bit
+// digits, which pays off on large slices of wide keys
+func RadixSort16[T constraints.Integer](s []T) {
+	radixSort(s, 16)
+}
+
+func radixSort[T constraints.Integer](s []T, digitBits int) {
+	if len(s) < 2 {
+		return
+	}
+	width := int(unsafe.Sizeof(s[0])) * 8
+	keyMask := ^uint64(0) >> (64 - width)
+	flip := uint64(0)
+	if ^T(0) < 0 {
+		flip = 1 << (width - 1)
+	}
+	// unsigned key of x in the low width bits, ordered like x
+	key := func(x T) uint64 { return (uint64(x) ^ flip) & keyMask }
+
+	mask := uint64(1)<<digitBits - 1
+	count := make([]int, mask+1)
+	src, dst := s, make([]T, len(s))
+	for shift := 0; shift < width; shift += digitBits {
+		for d := range count {
+			count[d] = 0
+		}
+		for _, x := range src {
+			count[key(x)>>shift&mask]++
+		}
+		if count[key(src[0])>>shift&mask] == len(src) {
+			continue
+		}
+		sum := 0
+		for d, c := range count {
+			count[d], sum = sum, sum+c
+		}
+		for _, x := range src {
+			d := key(x) >> shift & mask
+			dst[count[d]] = x
+			count[d]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+func minMax[T constraints.Integer](s []T) (T, T) {
+	lo, hi := s[0], s[0]
+	for _, x := range s[1:] {
+		if x < lo {
+			lo = x
+		} else if x > hi {
+			hi = x
+		}
+	}
+	return lo, hi
+}
+
+// CountingSort counts the occurrences of every value between the
+// smallest and the largest key, it takes O(n + max - min) time and
+// space so it suits keys from a small range. Keys spanning 64Ki
+// values more than the slice length are radix sorted instead.
+func CountingSort[T constraints.Integer](s []T) {
+	if len(s) < 2 {
+		return
+	}
+	lo, hi := minMax(s)
+	// unsigned difference, hi - lo may overflow T
+	if uint64(hi)-uint64(lo) >= uint64(len(s))+countingSortSlack {
+		radixSort(s, 8)
+		return
+	}
+	countingSort(s, lo, hi)
+}
+
+// counts allowed beyond the slice length
+const countingSortSlack = 1 << 16
+
+func countingSort[T constraints.Integer](s []T, lo, hi T) {
+	count := make([]int, uint64(hi)-uint64(lo)+1)
+	for _, x := range s {
+		count[uint64(x)-uint64(lo)]++
+	}
+	i := 0
+	for d, c := range count {
+		for v := lo + T(d); c > 0; c-- {
+			s[i] = v
+			i++
+		}
+	}
+}
+
+// slices up to this size are sorted with Qsort3way
+const intSortCutoff = 256
+
+// IntSort picks a sort for integer keys: Qsort3way for small
+// slices, counting sort when the keys span a range no larger than
+// the slice and radix sort on 8 bit digits otherwise, whose
+// counts stay in cache where the 16 bit ones do not
+func IntSort[T constraints.Integer](s []T) {
+	if len(s) <= intSortCutoff {
+		Qsort3wayOrdered(s)
+		return
+	}
+	lo, hi := minMax(s)
+	// unsigned difference, hi - lo may overflow T
+	if uint64(hi)-uint64(lo) < uint64(len(s)) {
+		countingSort(s, lo, hi)
+		return
+	}
+	radixSort(s, 8)
+}
@@ -0,0 +1,135 @@
+package algo_test
+
+import (
+	"algo"
+	"math"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+func testIntSorts[T constraints.Integer](t *testing.T, in []T) {
+	t.Helper()
+	expected := append([]T{}, in...)
+	slices.Sort(expected)
+	for _, fn := range []func([]T){algo.RadixSort[T], algo.RadixSort16[T], algo.IntSort[T]} {
+		s := append([]T{}, in...)
+		fn(s)
+		assert.DeepEqual(t, s, expected)
+	}
+}
+
+func TestAlgo_IntSort(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 100, 1000, 20000} {
+		ints, u64s, u32s, i8s := make([]int, n), make([]uint64, n), make([]uint32, n), make([]int8, n)
+		for i := 0; i < n; i++ {
+			ints[i] = int(rnd.Uint64())
+			u64s[i] = rnd.Uint64()
+			u32s[i] = rnd.Uint32()
+			i8s[i] = int8(rnd.Intn(256))
+		}
+		if n > 2 {
+			ints[0], ints[1], ints[2] = math.MinInt, math.MaxInt, 0
+			u64s[0], u64s[1] = 0, math.MaxUint64
+		}
+		testIntSorts(t, ints)
+		testIntSorts(t, u64s)
+		testIntSorts(t, u32s)
+		testIntSorts(t, i8s)
+
+		// small ranges go through counting sort
+		for i := range ints {
+			ints[i] = rnd.Intn(100) - 50
+			u32s[i] = uint32(rnd.Intn(10))
+		}
+		testIntSorts(t, ints)
+		testIntSorts(t, u32s)
+		s := append([]int{}, ints...)
+		algo.CountingSort(s)
+		assert.Assert(t, slices.IsSorted(s))
+		algo.CountingSort(i8s)
+		assert.Assert(t, slices.IsSorted(i8s))
+	}
+
+	// ranges too wide to count fall back to radix sort
+	wide := []int64{math.MaxInt64, math.MinInt64, 0, -1, 1}
+	algo.CountingSort(wide)
+	assert.DeepEqual(t, wide, []int64{math.MinInt64, -1, 0, 1, math.MaxInt64})
+	sparse := []uint64{1 << 40, 3, 0, 1 << 40}
+	algo.CountingSort(sparse)
+	assert.DeepEqual(t, sparse, []uint64{0, 3, 1 << 40, 1 << 40})
+}
+
+func benchmarkIntSort[T constraints.Integer](b *testing.B, input []T) {
+	sorts := []struct {
+		name string
+		fn   func([]T)
+	}{
+		{"Slices", slices.Sort[[]T]},
+		{"Qsort3way", algo.Qsort3wayOrdered[T]},
+		{"RadixSort", algo.RadixSort[T]},
+		{"RadixSort16", algo.RadixSort16[T]},
+		{"IntSort", algo.IntSort[T]},
+	}
+	for _, sc := range sorts {
+		b.Run(sc.name, func(b *testing.B) {
+			benchmarkSort(b, input, sc.fn)
+		})
+	}
+}
+
+const intSortBenchN = 1 << 20
+
+func BenchmarkIntSort_UniformInt(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]int, intSortBenchN)
+	for i := range s {
+		s[i] = int(rnd.Uint64())
+	}
+	benchmarkIntSort(b, s)
+}
+
+func BenchmarkIntSort_UniformUint32(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]uint32, intSortBenchN)
+	for i := range s {
+		s[i] = rnd.Uint32()
+	}
+	benchmarkIntSort(b, s)
+}
+
+// zipf distributed keys, a few values make up most of the slice
+func BenchmarkIntSort_SkewedUint64(b *testing.B) {
+	z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.2, 1, math.MaxUint32)
+	s := make([]uint64, intSortBenchN)
+	for i := range s {
+		s[i] = z.Uint64()
+	}
+	benchmarkIntSort(b, s)
+}
+
+// exponentially distributed keys, mostly small with a long tail
+func BenchmarkIntSort_SkewedInt(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]int, intSortBenchN)
+	for i := range s {
+		s[i] = int(rnd.ExpFloat64() * 1000)
+		if i%2 == 0 {
+			s[i] = -s[i]
+		}
+	}
+	benchmarkIntSort(b, s)
+}
+
+func BenchmarkIntSort_SmallRange(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]uint32, intSortBenchN)
+	for i := range s {
+		s[i] = uint32(rnd.Intn(1000))
+	}
+	benchmarkIntSort(b, s)
+}
@@ -1,9 +1,17 @@
 package algo
 
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+var emptyPatternErr = fmt.Errorf("pattern must not be empty")
+
 // build the brack track table for match string
-func bktrack(pat []rune) []int {
+func bktrack[T comparable](pat []T) []int {
 	bkt := make([]int, len(pat))
-	bkt[0] = 0
 	m, i := 0, 1 // m -> longest prefix/suffix match len for pat[:i+1]
 	for i < len(pat) {
 		if pat[i] == pat[m] {
@@ -31,8 +39,45 @@ func torune(s string) []rune {
 	return chars
 }
 
+// an empty pattern matches at every boundary, n < 0 means all
+func boundaries(l, n int) []int {
+	pos := []int{}
+	for i := 0; i <= l && len(pos) != n; i++ {
+		pos = append(pos, i)
+	}
+	return pos
+}
+
+// byte offsets of the rune boundaries of s, where an empty pattern
+// matches, n < 0 means all. Invalid bytes count as one rune each,
+// as in strings.Count.
+func runeBoundaries[S ~string | ~[]byte](s S, n int) []int {
+	pos := []int{}
+	for i := 0; len(pos) != n; {
+		pos = append(pos, i)
+		if i == len(s) {
+			break
+		}
+		end := i + utf8.UTFMax
+		if end > len(s) {
+			end = len(s)
+		}
+		_, size := utf8.DecodeRuneInString(string(s[i:end]))
+		i += size
+	}
+	return pos
+}
+
+// offsets are counted in runes, an empty pattern
+// matches at every rune boundary like strings.Count
 func KMP(s, pattern string) []int {
 	str, pat := torune(s), torune(pattern)
+	if len(pat) == 0 {
+		return boundaries(len(str), -1)
+	}
+	if len(str) < len(pat) {
+		return []int{}
+	}
 	bkt := bktrack(pat)
 	pos, i, j := []int{}, 0, 0
 
@@ -54,3 +99,165 @@ func KMP(s, pattern string) []int {
 
 	return pos
 }
+
+// match pattern over a string or byte slice, offsets are in bytes.
+// Overlapping matches are reported when overlap is set, otherwise
+// search resumes after the end of each match. n limits the number
+// of matches, n < 0 means all of them. An empty pattern matches at
+// every rune boundary, so offsets never split a rune.
+func kmpIndex[S ~string | ~[]byte](s S, pat []byte, bkt []int, overlap bool, n int) []int {
+	if len(pat) == 0 {
+		return runeBoundaries(s, n)
+	}
+	pos := []int{}
+	if n == 0 || len(s) < len(pat) {
+		return pos
+	}
+	i, j := 0, 0
+
+	for j < len(s) {
+		if s[j] == pat[i] {
+			if i == len(pat)-1 {
+				pos = append(pos, j-i)
+				if len(pos) == n {
+					break
+				}
+				if overlap {
+					i = bkt[i]
+				} else {
+					i = 0
+				}
+			} else {
+				i++
+			}
+			j++
+		} else if i > 0 {
+			i = bkt[i-1]
+		} else {
+			j++
+		}
+	}
+
+	return pos
+}
+
+// KMPIndex is KMP returning byte offsets, usable to slice s
+func KMPIndex(s, pattern string) []int {
+	pat := []byte(pattern)
+	return kmpIndex(s, pat, bktrack(pat), true, -1)
+}
+
+func KMPIndexN(s, pattern string, overlap bool, n int) []int {
+	pat := []byte(pattern)
+	return kmpIndex(s, pat, bktrack(pat), overlap, n)
+}
+
+func KMPBytes(s, pattern []byte) []int {
+	return kmpIndex(s, pattern, bktrack(pattern), true, -1)
+}
+
+func KMPBytesN(s, pattern []byte, overlap bool, n int) []int {
+	return kmpIndex(s, pattern, bktrack(pattern), overlap, n)
+}
+
+// Pattern is a validated, non empty pattern with its
+// bktrack table computed once for repeated searches
+type Pattern struct {
+	pat []byte
+	bkt []int
+}
+
+func CompilePattern(pattern string) (*Pattern, error) {
+	if pattern == "" {
+		return nil, emptyPatternErr
+	}
+	pat := []byte(pattern)
+	return &Pattern{pat: pat, bkt: bktrack(pat)}, nil
+}
+
+func (p *Pattern) String() string { return string(p.pat) }
+
+// byte offsets of all overlapping matches
+func (p *Pattern) FindAll(s string) []int {
+	return kmpIndex(s, p.pat, p.bkt, true, -1)
+}
+
+func (p *Pattern) FindAllN(s string, overlap bool, n int) []int {
+	return kmpIndex(s, p.pat, p.bkt, overlap, n)
+}
+
+func (p *Pattern) FindAllBytes(b []byte) []int {
+	return kmpIndex(b, p.pat, p.bkt, true, -1)
+}
+
+const matcherBufSize = 32 * 1024
+
+// Matcher is a KMP pattern compiled once for
+// scanning byte streams of any length
+type Matcher struct {
+	pat []byte
+	bkt []int
+}
+
+func NewMatcher(pattern string) *Matcher {
+	pat := []byte(pattern)
+	return &Matcher{pat: pat, bkt: bktrack(pat)}
+}
+
+// Scan calls fn with the byte offset of every match as soon as
+// its last byte is read, stopping early when fn returns false.
+// Only the match state is kept between reads, so matches may span
+// buffer boundaries and memory use does not grow with the input.
+// An empty pattern matches at every rune boundary, as in KMPIndex.
+func (m *Matcher) Scan(r io.Reader, fn func(offset int) bool) error {
+	if len(m.pat) == 0 {
+		return scanRuneBoundaries(r, fn)
+	}
+	buf := make([]byte, matcherBufSize)
+	i, off := 0, 0
+	for {
+		n, err := r.Read(buf)
+		for _, c := range buf[:n] {
+			for {
+				if c == m.pat[i] {
+					if i < len(m.pat)-1 {
+						i++
+					} else {
+						if !fn(off + 1 - len(m.pat)) {
+							return nil
+						}
+						i = m.bkt[i]
+					}
+					break
+				}
+				if i == 0 {
+					break
+				}
+				i = m.bkt[i-1]
+			}
+			off++
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func scanRuneBoundaries(r io.Reader, fn func(offset int) bool) error {
+	br := bufio.NewReaderSize(r, matcherBufSize)
+	off := 0
+	for fn(off) {
+		_, size, err := br.ReadRune()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		off += size
+	}
+	return nil
+}
@@ -2,7 +2,12 @@ package algo_test
 
 import (
 	"algo"
+	"errors"
+	"math/rand"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"unicode/utf8"
 
 	"gotest.tools/v3/assert"
 )
@@ -11,4 +16,136 @@ func TestAlgo_KMP(t *testing.T) {
 	assert.DeepEqual(t, algo.KMP("abababab", "d"), []int{})
 	assert.DeepEqual(t, algo.KMP("abababab", "ab"), []int{0, 2, 4, 6})
 	assert.DeepEqual(t, algo.KMP("hel你好lo,你好", "你好"), []int{3, 8})
+	assert.DeepEqual(t, algo.KMP("你好", ""), []int{0, 1, 2})
+	assert.DeepEqual(t, algo.KMP("", "ab"), []int{})
+	assert.DeepEqual(t, algo.KMP("", ""), []int{0})
+}
+
+func TestAlgo_KMPPattern(t *testing.T) {
+	_, err := algo.CompilePattern("")
+	assert.Error(t, err, "pattern must not be empty")
+
+	p, err := algo.CompilePattern("aa")
+	assert.NilError(t, err)
+	assert.Equal(t, p.String(), "aa")
+	assert.DeepEqual(t, p.FindAll("aaaa"), []int{0, 1, 2})
+	assert.DeepEqual(t, p.FindAllN("aaaa", false, -1), []int{0, 2})
+	assert.DeepEqual(t, p.FindAllBytes([]byte("baab")), []int{1})
+
+	assert.DeepEqual(t, algo.KMPIndex("你好", ""), []int{0, 3, 6})
+	assert.DeepEqual(t, algo.KMPIndexN("你好", "", true, 2), []int{0, 3})
+	assert.DeepEqual(t, algo.KMPBytes([]byte("a\xe4\xbd你"), nil), []int{0, 1, 2, 3, 6})
+	assert.DeepEqual(t, algo.KMPIndexN("abc", "", false, 2), []int{0, 1})
+}
+
+func naiveIndex(s, pat string, overlap bool) []int {
+	pos := []int{}
+	if pat == "" {
+		// rune boundaries, like strings.Count
+		for i := range s {
+			pos = append(pos, i)
+		}
+		return append(pos, len(s))
+	}
+	for i := 0; i+len(pat) <= len(s); i++ {
+		if s[i:i+len(pat)] == pat {
+			pos = append(pos, i)
+			if !overlap && len(pat) > 0 {
+				i += len(pat) - 1
+			}
+		}
+	}
+	return pos
+}
+
+func naiveRuneIndex(s, pat string) []int {
+	str, p := []rune(s), []rune(pat)
+	pos := []int{}
+	for i := 0; i+len(p) <= len(str); i++ {
+		if string(str[i:i+len(p)]) == string(p) {
+			pos = append(pos, i)
+		}
+	}
+	return pos
+}
+
+func FuzzKMP(f *testing.F) {
+	f.Add("abababab", "ab")
+	f.Add("aaaa", "aa")
+	f.Add("hel你好lo,你好", "你好")
+	f.Add("", "")
+	f.Add("abc", "")
+	f.Add("abcabd", "abd")
+	f.Fuzz(func(t *testing.T, s, pat string) {
+		assert.DeepEqual(t, algo.KMPIndex(s, pat), naiveIndex(s, pat, true))
+		assert.DeepEqual(t, algo.KMPIndexN(s, pat, false, -1), naiveIndex(s, pat, false))
+		assert.DeepEqual(t, algo.KMPBytes([]byte(s), []byte(pat)), naiveIndex(s, pat, true))
+		if p, err := algo.CompilePattern(pat); err == nil {
+			assert.DeepEqual(t, p.FindAll(s), naiveIndex(s, pat, true))
+		}
+		if utf8.ValidString(s) && utf8.ValidString(pat) {
+			assert.DeepEqual(t, algo.KMP(s, pat), naiveRuneIndex(s, pat))
+		}
+	})
+}
+
+func TestAlgo_KMPIndex(t *testing.T) {
+	s := "hel你好lo,你好"
+	pos := algo.KMPIndex(s, "你好")
+	assert.DeepEqual(t, pos, []int{3, 12})
+	for _, p := range pos {
+		assert.Assert(t, strings.HasPrefix(s[p:], "你好"))
+	}
+	assert.DeepEqual(t, algo.KMPBytes([]byte("aaaa"), []byte("aa")), []int{0, 1, 2})
+	assert.DeepEqual(t, algo.KMPBytesN([]byte("aaaa"), []byte("aa"), false, -1), []int{0, 2})
+	assert.DeepEqual(t, algo.KMPIndexN("abababab", "aba", true, 2), []int{0, 2})
+	assert.DeepEqual(t, algo.KMPIndexN("abababab", "aba", false, -1), []int{0, 4})
+	assert.DeepEqual(t, algo.KMPIndexN("abababab", "ab", true, 0), []int{})
+
+	b := make([]byte, 10000)
+	for i := range b {
+		b[i] = "ab"[rand.Intn(2)]
+	}
+	text := string(b)
+	assert.DeepEqual(t, algo.KMPIndex(text, "abab"), algo.KMP(text, "abab"))
+	pos = algo.KMPIndexN(text, "abab", false, -1)
+	assert.Equal(t, len(pos), strings.Count(text, "abab"))
+	assert.Equal(t, pos[0], strings.Index(text, "abab"))
+}
+
+func TestAlgo_KMPMatcher(t *testing.T) {
+	scan := func(m *algo.Matcher, text string) []int {
+		pos := []int{}
+		r := iotest.HalfReader(strings.NewReader(text))
+		assert.NilError(t, m.Scan(r, func(off int) bool {
+			pos = append(pos, off)
+			return true
+		}))
+		return pos
+	}
+
+	assert.DeepEqual(t, scan(algo.NewMatcher("aba"), "abababa"), []int{0, 2, 4})
+	assert.DeepEqual(t, scan(algo.NewMatcher("你好"), "hel你好lo,你好"), []int{3, 12})
+	assert.DeepEqual(t, scan(algo.NewMatcher("d"), "abababab"), []int{})
+	assert.DeepEqual(t, scan(algo.NewMatcher(""), "abc"), []int{0, 1, 2, 3})
+	assert.DeepEqual(t, scan(algo.NewMatcher(""), "a你\xe4\xbd好"), []int{0, 1, 4, 5, 6, 9})
+
+	// matches spanning the internal read buffer
+	b := make([]byte, 200000)
+	for i := range b {
+		b[i] = "ab"[rand.Intn(2)]
+	}
+	text := string(b)
+	m := algo.NewMatcher("abba")
+	assert.DeepEqual(t, scan(m, text), algo.KMP(text, "abba"))
+
+	first := -1
+	assert.NilError(t, m.Scan(strings.NewReader(text), func(off int) bool {
+		first = off
+		return false
+	}))
+	assert.Equal(t, first, strings.Index(text, "abba"))
+
+	failing := iotest.ErrReader(errors.New("read failed"))
+	assert.Error(t, m.Scan(failing, func(int) bool { return true }), "read failed")
 }
@@ -0,0 +1,64 @@
+package algo
+
+import "golang.org/x/exp/constraints"
+
+// merge the sorted halves s[:mid] and s[mid:] through aux,
+// taking from the left half on ties to keep the sort stable
+func merge[T any](s, aux []T, mid int, cmp func(a, b T) int) {
+	copy(aux, s)
+	i, j := 0, mid
+	for k := range s {
+		if i < mid && (j >= len(s) || cmp(aux[j], aux[i]) >= 0) {
+			s[k] = aux[i]
+			i++
+		} else {
+			s[k] = aux[j]
+			j++
+		}
+	}
+}
+
+// MergeSort is a stable top down merge sort, small slices are
+// insertion sorted and halves already in order are not merged
+func MergeSort[T constraints.Ordered](s []T) {
+	MergeSortFunc(s, cmpOrdered[T])
+}
+
+func MergeSortFunc[T any](s []T, cmp func(a, b T) int) {
+	mergeSort(s, make([]T, len(s)), cmp)
+}
+
+func mergeSort[T any](s, aux []T, cmp func(a, b T) int) {
+	if len(s) <= insertionCutoff {
+		(&qsorter[T]{cmp: cmp}).insertion(s)
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], aux[:mid], cmp)
+	mergeSort(s[mid:], aux[mid:], cmp)
+	if cmp(s[mid-1], s[mid]) <= 0 {
+		return
+	}
+	merge(s, aux, mid, cmp)
+}
+
+// MergeSortBottomUp merges runs of width 1, 2, 4 ... in passes
+// over the whole slice, without recursion
+func MergeSortBottomUp[T constraints.Ordered](s []T) {
+	MergeSortBottomUpFunc(s, cmpOrdered[T])
+}
+
+func MergeSortBottomUpFunc[T any](s []T, cmp func(a, b T) int) {
+	aux := make([]T, len(s))
+	for w := 1; w < len(s); w *= 2 {
+		for lo := 0; lo+w < len(s); lo += 2 * w {
+			hi := lo + 2*w
+			if hi > len(s) {
+				hi = len(s)
+			}
+			if cmp(s[lo+w-1], s[lo+w]) > 0 {
+				merge(s[lo:hi], aux[lo:hi], w, cmp)
+			}
+		}
+	}
+}
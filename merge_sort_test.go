@@ -0,0 +1,141 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+var stableSorts = []struct {
+	name string
+	fn   func([]person, func(a, b person) int)
+}{
+	{"MergeSort", algo.MergeSortFunc[person]},
+	{"MergeSortBottomUp", algo.MergeSortBottomUpFunc[person]},
+	{"TimSort", algo.TimSortFunc[person]},
+	{"BlockMergeSort", algo.BlockMergeSortFunc[person]},
+}
+
+// ages shaped like real world input, names record the input order
+func peopleShaped(rnd *rand.Rand, n int, shape string) []person {
+	people := make([]person, n)
+	for i := range people {
+		age := rnd.Intn(50)
+		switch shape {
+		case "sorted":
+			age = i / 7
+		case "reversed":
+			age = (n - i) / 7
+		case "runs":
+			age = i%100 + rnd.Intn(3)
+		case "sawtooth":
+			age = (i % 37) * (1 - 2*(i/37%2))
+		}
+		people[i] = person{fmt.Sprint(i), age}
+	}
+	return people
+}
+
+func TestAlgo_StableSort(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	byAge := func(a, b person) int { return a.age - b.age }
+	for _, n := range []int{0, 1, 2, 19, 21, 64, 1000, 5000} {
+		for _, shape := range []string{"random", "sorted", "reversed", "runs", "sawtooth"} {
+			people := peopleShaped(rnd, n, shape)
+			expected := append([]person{}, people...)
+			sort.SliceStable(expected, func(i, j int) bool { return expected[i].age < expected[j].age })
+			for _, sc := range stableSorts {
+				s := append([]person{}, people...)
+				sc.fn(s, byAge)
+				for i := range s {
+					assert.Equal(t, s[i], expected[i], sc.name)
+				}
+			}
+		}
+	}
+
+	ints := rand.Perm(3000)
+	for _, fn := range []func([]int){algo.MergeSort[int], algo.MergeSortBottomUp[int], algo.TimSort[int], algo.BlockMergeSort[int]} {
+		s := append([]int{}, ints...)
+		fn(s)
+		assert.Assert(t, sort.IntsAreSorted(s))
+	}
+	strs := []string{"b", "a", "c", "a"}
+	algo.BlockMergeSort(strs)
+	assert.DeepEqual(t, strs, []string{"a", "a", "b", "c"})
+}
+
+func TestAlgo_BlockMergeSort(t *testing.T) {
+	// the number of distinct keys picks how runs are merged: through
+	// a buffer, by rotating tagged blocks or by rotating whole runs
+	rnd := rand.New(rand.NewSource(1))
+	n := 50000
+	for _, distinct := range []int{1, 2, 10, 300, 600, 1000, n} {
+		people := make([]person, n)
+		for i := range people {
+			people[i] = person{fmt.Sprint(i), rnd.Intn(distinct)}
+		}
+		expected := append([]person{}, people...)
+		sort.SliceStable(expected, func(i, j int) bool { return expected[i].age < expected[j].age })
+		ncmp := 0
+		algo.BlockMergeSortFunc(people, func(a, b person) int { ncmp++; return a.age - b.age })
+		for i := range people {
+			assert.Equal(t, people[i], expected[i], distinct)
+		}
+		assert.Assert(t, ncmp < 3*n*bits.Len(uint(n)), ncmp)
+	}
+
+	ints, s := rand.Perm(n), make([]int, n)
+	allocs := testing.AllocsPerRun(3, func() {
+		copy(s, ints)
+		algo.BlockMergeSort(s)
+	})
+	assert.Equal(t, allocs, 0.0)
+	assert.Assert(t, sort.IntsAreSorted(s))
+}
+
+// sorted except for every tenth element
+func partiallySorted(n int) []int {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+		if i%10 == 0 {
+			s[i] = rnd.Intn(n)
+		}
+	}
+	return s
+}
+
+func BenchmarkStableSort(b *testing.B) {
+	inputs := []struct {
+		name string
+		s    []int
+	}{
+		{"random", rand.New(rand.NewSource(1)).Perm(100000)},
+		{"partial", partiallySorted(100000)},
+	}
+	sorts := []struct {
+		name string
+		fn   func([]int)
+	}{
+		{"SliceStable", func(s []int) { slices.SortStableFunc(s, func(a, b int) int { return a - b }) }},
+		{"MergeSort", algo.MergeSort[int]},
+		{"MergeSortBottomUp", algo.MergeSortBottomUp[int]},
+		{"TimSort", algo.TimSort[int]},
+		{"BlockMergeSort", algo.BlockMergeSort[int]},
+	}
+	for _, in := range inputs {
+		for _, sc := range sorts {
+			b.Run(in.name+"/"+sc.name, func(b *testing.B) {
+				benchmarkSort(b, in.s, sc.fn)
+			})
+		}
+	}
+}
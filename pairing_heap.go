@@ -0,0 +1,113 @@
+package algo
+
+// leftmost child, siblings are linked through the next field
+type pairingNode[T any] struct {
+	item  T
+	child *pairingNode[T]
+	next  *pairingNode[T]
+}
+
+// PairingHeap is a heap ordered tree where push and meld link
+// two trees in O(1), the work is deferred to Pop, which pairs up
+// the children of the root in O(log n) amortized time
+type PairingHeap[T any] struct {
+	root *pairingNode[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+func NewPairingHeap[T any](cmp func(a, b T) int) *PairingHeap[T] {
+	return &PairingHeap[T]{cmp: cmp}
+}
+
+func (h *PairingHeap[T]) Len() int { return h.size }
+
+func (h *PairingHeap[T]) IsEmpty() bool { return h.size == 0 }
+
+func (h *PairingHeap[T]) Check() bool {
+	if h.root == nil {
+		return h.size == 0
+	}
+	cnt, stack := 0, []*pairingNode[T]{h.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cnt++
+		for c := n.child; c != nil; c = c.next {
+			if h.cmp(n.item, c.item) > 0 {
+				return false
+			}
+			stack = append(stack, c)
+		}
+	}
+	return cnt == h.size && h.root.next == nil
+}
+
+// the root with the larger item becomes the first child of the other
+func (h *PairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.item, a.item) < 0 {
+		a, b = b, a
+	}
+	b.next = a.child
+	a.child = b
+	return a
+}
+
+func (h *PairingHeap[T]) Push(x T) {
+	h.root = h.link(h.root, &pairingNode[T]{item: x})
+	h.size++
+}
+
+// Meld moves all elements of o into h in O(1), leaving o empty.
+// Both heaps must be ordered by the same cmp.
+func (h *PairingHeap[T]) Meld(o *PairingHeap[T]) {
+	if o == h {
+		return
+	}
+	h.root = h.link(h.root, o.root)
+	h.size += o.size
+	o.root, o.size = nil, 0
+}
+
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.item, true
+}
+
+// Pop links the children of the root in pairs from left to right,
+// then links the pairs from right to left into the new root
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	top := h.root.item
+	pairs := []*pairingNode[T]{}
+	for c := h.root.child; c != nil; {
+		a, b := c, c.next
+		if b == nil {
+			c = nil
+		} else {
+			c = b.next
+			b.next = nil
+		}
+		a.next = nil
+		pairs = append(pairs, h.link(a, b))
+	}
+	var root *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	h.root = root
+	h.size--
+	return top, true
+}
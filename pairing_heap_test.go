@@ -0,0 +1,39 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_PairingHeap(t *testing.T) {
+	byValue := func(a, b int) int { return a - b }
+	testHeap(t, algo.NewPairingHeap(byValue))
+
+	h, o := algo.NewPairingHeap(byValue), algo.NewPairingHeap(byValue)
+	model := pqModel{}
+	for i := 0; i < 5000; i++ {
+		x := rand.Intn(1000)
+		if i%2 == 0 {
+			h.Push(x)
+		} else {
+			o.Push(x)
+		}
+		model.push(x)
+		if i%500 == 0 {
+			h.Meld(o)
+			assert.Assert(t, o.IsEmpty())
+			assert.Assert(t, h.Check(), "meld violates heap")
+		}
+	}
+	h.Meld(o)
+	h.Meld(h)
+	assert.Equal(t, h.Len(), len(model))
+	for len(model) > 0 {
+		x, _ := h.Pop()
+		assert.Equal(t, x, model.pop())
+		assert.Assert(t, h.Check(), "pop violates heap")
+	}
+}
@@ -0,0 +1,78 @@
+package algo
+
+import (
+	"context"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+// partitions below this size are sorted by the goroutine
+// that produced them, forking costs more than it saves
+const parallelThreshold = 1 << 13
+
+// ParallelQsort3way sorts s with up to workers goroutines, see
+// ParallelQsort3wayFunc
+func ParallelQsort3way[T constraints.Ordered](ctx context.Context, s []T, workers int) error {
+	return ParallelQsort3wayFunc(ctx, s, cmpOrdered[T], workers)
+}
+
+// ParallelQsort3wayFunc hands the smaller side of each large
+// partition to a new goroutine while a worker slot is free and
+// keeps partitioning the larger side itself. Pivots are Tukey's
+// ninther so workers share no random source, with the introsort
+// depth bound as a fallback. workers < 1 means GOMAXPROCS. On
+// cancellation it stops early, leaving s partially sorted, and
+// returns the context error.
+func ParallelQsort3wayFunc[T any](ctx context.Context, s []T, cmp func(a, b T) int, workers int) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	q := &qsorter[T]{cmp: cmp, pivot: PivotNinther}
+	sem := make(chan struct{}, workers-1)
+	wg := sync.WaitGroup{}
+	canceled := atomic.Bool{}
+
+	var sortPart func(s []T, depth int)
+	sortPart = func(s []T, depth int) {
+		for len(s) > parallelThreshold {
+			if ctx.Err() != nil {
+				canceled.Store(true)
+				return
+			}
+			if depth == 0 {
+				q.heapsort(s)
+				return
+			}
+			depth--
+			i, k := q.partition(s)
+			small, large := s[:i], s[k:]
+			if len(small) > len(large) {
+				small, large = large, small
+			}
+			select {
+			case sem <- struct{}{}:
+				wg.Add(1)
+				go func(part []T, depth int) {
+					defer wg.Done()
+					sortPart(part, depth)
+					<-sem
+				}(small, depth)
+			default:
+				sortPart(small, depth)
+			}
+			s = large
+		}
+		q.introsort(s, depth)
+	}
+
+	sortPart(s, 2*bits.Len(uint(len(s))))
+	wg.Wait()
+	if canceled.Load() {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package algo_test
+
+import (
+	"algo"
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_ParallelQsort3way(t *testing.T) {
+	for _, workers := range []int{0, 1, 4, 16} {
+		s := make([]int, 200000)
+		for i := range s {
+			s[i] = rand.Intn(1000)
+		}
+		assert.NilError(t, algo.ParallelQsort3way(context.Background(), s, workers))
+		assert.Assert(t, sort.IntsAreSorted(s))
+	}
+
+	people := []person{}
+	for i := 0; i < 100000; i++ {
+		people = append(people, person{"", rand.Intn(100000)})
+	}
+	assert.NilError(t, algo.ParallelQsort3wayFunc(context.Background(), people,
+		func(a, b person) int { return a.age - b.age }, 8))
+	for i := 0; i < len(people)-1; i++ {
+		assert.Assert(t, people[i].age <= people[i+1].age)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := rand.Perm(100000)
+	assert.Error(t, algo.ParallelQsort3way(ctx, s, 4), "context canceled")
+
+	// small inputs never check the context
+	small := rand.Perm(100)
+	assert.NilError(t, algo.ParallelQsort3way(ctx, small, 4))
+	assert.Assert(t, sort.IntsAreSorted(small))
+}
+
+// benchmarkSort times sortFn on a fresh copy of input per iteration
+func benchmarkSort[T any](b *testing.B, input []T, sortFn func([]T)) {
+	s := make([]T, len(input))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(s, input)
+		b.StartTimer()
+		sortFn(s)
+	}
+}
+
+func sortBenchInput() []int {
+	return rand.New(rand.NewSource(1)).Perm(1 << 22)
+}
+
+func BenchmarkSort_Qsort3way(b *testing.B) {
+	benchmarkSort(b, sortBenchInput(), algo.Qsort3way)
+}
+
+func BenchmarkSort_Slices(b *testing.B) {
+	benchmarkSort(b, sortBenchInput(), slices.Sort[[]int])
+}
+
+func BenchmarkSort_ParallelQsort3way(b *testing.B) {
+	benchmarkSort(b, sortBenchInput(), func(s []int) {
+		algo.ParallelQsort3way(context.Background(), s, 0)
+	})
+}
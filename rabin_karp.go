@@ -0,0 +1,53 @@
+package algo
+
+// largest prime below 2^32, keeps every product within uint64
+const (
+	rkPrime = 4294967291
+	rkBase  = 256
+)
+
+// RabinKarp compares rolling hashes of the text windows with
+// the pattern hash, verifying candidates to rule out collisions
+type RabinKarp struct {
+	pat  []byte
+	hash uint64
+	rm   uint64 // base^(m-1) % prime, weight of the leading char
+}
+
+func rkHash(s string) uint64 {
+	h := uint64(0)
+	for i := 0; i < len(s); i++ {
+		h = (h*rkBase + uint64(s[i])) % rkPrime
+	}
+	return h
+}
+
+func NewRabinKarp(pattern string) (*RabinKarp, error) {
+	if pattern == "" {
+		return nil, emptyPatternErr
+	}
+	rk := &RabinKarp{pat: []byte(pattern), hash: rkHash(pattern), rm: 1}
+	for i := 1; i < len(pattern); i++ {
+		rk.rm = rk.rm * rkBase % rkPrime
+	}
+	return rk, nil
+}
+
+func (rk *RabinKarp) FindAll(s string) []int {
+	pos, m := []int{}, len(rk.pat)
+	if len(s) < m {
+		return pos
+	}
+	h := rkHash(s[:m])
+	for i := 0; ; i++ {
+		if h == rk.hash && s[i:i+m] == string(rk.pat) {
+			pos = append(pos, i)
+		}
+		if i+m == len(s) {
+			return pos
+		}
+		// drop s[i], add s[i+m]
+		h = (h + rkPrime - rk.rm*uint64(s[i])%rkPrime) % rkPrime
+		h = (h*rkBase + uint64(s[i+m])) % rkPrime
+	}
+}
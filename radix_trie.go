@@ -0,0 +1,226 @@
+package algo
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// single-child chains are collapsed into the edge label,
+// childs are kept ordered by the first byte of their labels
+type radixNode struct {
+	label    []byte
+	childs   []*radixNode
+	isString bool
+}
+
+func commonPrefixLen(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func (n *radixNode) print() {
+	if n == nil {
+		return
+	}
+
+	for _, c := range n.childs {
+		fmt.Printf("%s[%v] ", c.label, c.isString)
+		c.print()
+		fmt.Println("")
+	}
+}
+
+// index of the child whose label starts with c, or the
+// position to insert such a child when it does not exist
+func (n *radixNode) index(c byte) (int, bool) {
+	i := sort.Search(len(n.childs), func(i int) bool {
+		return n.childs[i].label[0] >= c
+	})
+	return i, i < len(n.childs) && n.childs[i].label[0] == c
+}
+
+func (n *radixNode) get(s []byte) *radixNode {
+	if len(s) == 0 {
+		return n
+	}
+	i, ok := n.index(s[0])
+	if !ok {
+		return nil
+	}
+	c := n.childs[i]
+	if !bytes.HasPrefix(s, c.label) {
+		return nil
+	}
+	return c.get(s[len(c.label):])
+}
+
+// find the top node of the subtree holding all keys prefixed by s,
+// prefix may end in the middle of an edge label, the returned path
+// is the full key leading to the node
+func (n *radixNode) find(s, path []byte) (*radixNode, []byte) {
+	if len(s) == 0 {
+		return n, path
+	}
+	i, ok := n.index(s[0])
+	if !ok {
+		return nil, nil
+	}
+	c := n.childs[i]
+	p := commonPrefixLen(c.label, s)
+	if p == len(s) {
+		return c, append(path, c.label...)
+	}
+	if p < len(c.label) {
+		return nil, nil
+	}
+	return c.find(s[p:], append(path, c.label...))
+}
+
+func (n *radixNode) add(s []byte) {
+	if len(s) == 0 {
+		n.isString = true
+		return
+	}
+
+	i, ok := n.index(s[0])
+	if !ok {
+		leaf := &radixNode{label: append([]byte{}, s...), isString: true}
+		n.childs = append(n.childs, nil)
+		copy(n.childs[i+1:], n.childs[i:])
+		n.childs[i] = leaf
+		return
+	}
+
+	c := n.childs[i]
+	p := commonPrefixLen(c.label, s)
+	if p < len(c.label) {
+		// split the edge at the divergence point
+		mid := &radixNode{label: c.label[:p:p], childs: []*radixNode{c}}
+		c.label = c.label[p:]
+		n.childs[i] = mid
+		c = mid
+	}
+	c.add(s[p:])
+}
+
+func (n *radixNode) remove(s []byte) {
+	if len(s) == 0 {
+		n.isString = false
+		return
+	}
+
+	i, ok := n.index(s[0])
+	if !ok {
+		return
+	}
+	c := n.childs[i]
+	if !bytes.HasPrefix(s, c.label) {
+		return
+	}
+	c.remove(s[len(c.label):])
+
+	if c = c.compact(); c == nil {
+		n.childs = append(n.childs[:i], n.childs[i+1:]...)
+	} else {
+		n.childs[i] = c
+	}
+}
+
+// drop a node holding no keys, or merge it with its only child
+func (n *radixNode) compact() *radixNode {
+	if n.isString {
+		return n
+	}
+
+	switch len(n.childs) {
+	case 0:
+		return nil
+	case 1:
+		c := n.childs[0]
+		label := make([]byte, 0, len(n.label)+len(c.label))
+		c.label = append(append(label, n.label...), c.label...)
+		return c
+	}
+
+	return n
+}
+
+func (n *radixNode) collect(path []byte, results []string) []string {
+	if n.isString {
+		results = append(results, string(path))
+	}
+	for _, c := range n.childs {
+		results = c.collect(append(path, c.label...), results)
+	}
+	return results
+}
+
+// RadixTrie is a path compressed TrieSet, it only allocates
+// nodes where keys branch off or end
+type RadixTrie struct {
+	root *radixNode
+}
+
+func (s *RadixTrie) Print() {
+	s.root.print()
+}
+
+func (s *RadixTrie) IsEmpty() bool {
+	return s.root == nil
+}
+
+func (s *RadixTrie) Contains(key string) bool {
+	bs, err := sanitize(key)
+	if err != nil || s.root == nil {
+		return false
+	}
+	n := s.root.get(bs)
+	return n != nil && n.isString
+}
+
+func (s *RadixTrie) Del(key string) error {
+	bs, err := sanitize(key)
+	if err != nil {
+		return err
+	}
+	if s.root == nil {
+		return nil
+	}
+
+	s.root.remove(bs)
+	if !s.root.isString && len(s.root.childs) == 0 {
+		s.root = nil
+	}
+	return nil
+}
+
+func (s *RadixTrie) Put(key string) error {
+	bs, err := sanitize(key)
+	if err != nil {
+		return err
+	}
+
+	if s.root == nil {
+		s.root = &radixNode{}
+	}
+	s.root.add(bs)
+	return nil
+}
+
+func (s *RadixTrie) KeysWithPrefix(prefix string) []string {
+	bs, err := sanitize(prefix)
+	if err != nil || s.root == nil {
+		return nil
+	}
+
+	n, path := s.root.find(bs, nil)
+	if n == nil {
+		return nil
+	}
+
+	return n.collect(path, []string{})
+}
@@ -0,0 +1,96 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_RadixTrie(t *testing.T) {
+	set := algo.RadixTrie{}
+	assert.Assert(t, set.IsEmpty())
+
+	assert.NilError(t, set.Put("hello"))
+	assert.Assert(t, set.Contains("hello"))
+	assert.Assert(t, !set.Contains("hell"))
+	assert.Error(t, set.Put("你好"), "only supports ascii chars 0-127")
+	assert.NilError(t, set.Put("bbc"))
+	assert.NilError(t, set.Put("mill"))
+	assert.NilError(t, set.Put("million"))
+	assert.DeepEqual(t, set.KeysWithPrefix("mi"), []string{"mill", "million"})
+	assert.DeepEqual(t, set.KeysWithPrefix("milli"), []string{"million"})
+	assert.Assert(t, set.KeysWithPrefix("mx") == nil)
+	assert.NilError(t, set.Del("hello"))
+	assert.Assert(t, !set.Contains("hello"))
+	assert.NilError(t, set.Del("mill"))
+	assert.Assert(t, set.Contains("million"))
+	assert.NilError(t, set.Del("bbc"))
+	assert.NilError(t, set.Del("million"))
+	assert.Assert(t, set.IsEmpty())
+
+	radix, trie := algo.RadixTrie{}, algo.TrieSet{}
+	keys := []string{}
+	for i := 0; i < 2000; i++ {
+		k := fmt.Sprintf("%x", rand.Intn(5000))
+		keys = append(keys, k)
+		assert.NilError(t, radix.Put(k))
+		assert.NilError(t, trie.Put(k))
+	}
+	for _, p := range []string{"", "1", "a", "ff", "12"} {
+		assert.DeepEqual(t, radix.KeysWithPrefix(p), trie.KeysWithPrefix(p))
+	}
+	for _, k := range keys {
+		assert.NilError(t, radix.Del(k))
+		assert.Assert(t, !radix.Contains(k))
+	}
+	assert.Assert(t, radix.IsEmpty())
+}
+
+func urlKeys(n int) []string {
+	keys := []string{}
+	for i := 0; i < n; i++ {
+		keys = append(keys, fmt.Sprintf("https://example.com/users/%d/items/%d",
+			rand.Intn(n), i))
+	}
+	return keys
+}
+
+func benchmarkTrieMem(b *testing.B, keys []string, build func([]string) any) {
+	var ms runtime.MemStats
+	total := int64(0)
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		before := ms.HeapAlloc
+		set := build(keys)
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		total += int64(ms.HeapAlloc) - int64(before)
+		runtime.KeepAlive(set)
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "heap-B/op")
+}
+
+func BenchmarkMem_TrieSet(b *testing.B) {
+	benchmarkTrieMem(b, urlKeys(1000), func(keys []string) any {
+		set := &algo.TrieSet{}
+		for _, k := range keys {
+			set.Put(k)
+		}
+		return set
+	})
+}
+
+func BenchmarkMem_RadixTrie(b *testing.B) {
+	benchmarkTrieMem(b, urlKeys(1000), func(keys []string) any {
+		set := &algo.RadixTrie{}
+		for _, k := range keys {
+			set.Put(k)
+		}
+		return set
+	})
+}
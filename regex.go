@@ -0,0 +1,457 @@
+package algo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 256 bit set of bytes accepted by a char or class state
+type byteSet [4]uint64
+
+func (b *byteSet) add(c byte) { b[c/64] |= 1 << (c % 64) }
+
+func (b *byteSet) has(c byte) bool { return b[c/64]&(1<<(c%64)) != 0 }
+
+func (b *byteSet) addRange(lo, hi byte) {
+	for c := int(lo); c <= int(hi); c++ {
+		b.add(byte(c))
+	}
+}
+
+func (b *byteSet) union(o *byteSet) {
+	for i := range b {
+		b[i] |= o[i]
+	}
+}
+
+func (b *byteSet) negate() {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+}
+
+type nfaOp int
+
+const (
+	nfaByte  nfaOp = iota // consume a byte in set
+	nfaSplit              // epsilon to out and out1
+	nfaJump               // epsilon to out
+	nfaBegin              // epsilon to out at input start
+	nfaEnd                // epsilon to out at input end
+	nfaMatch
+)
+
+type nfaState struct {
+	op   nfaOp
+	set  byteSet
+	out  int
+	out1 int
+}
+
+// partially built automaton, outs are the dangling
+// exits still to be patched to the next fragment
+type nfaFrag struct {
+	start int
+	outs  []*int
+}
+
+type regexParser struct {
+	expr   string
+	pos    int
+	states []*nfaState
+}
+
+func (p *regexParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("regex %q at %d: %s", p.expr, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *regexParser) state(op nfaOp) (int, *nfaState) {
+	s := &nfaState{op: op, out: -1, out1: -1}
+	p.states = append(p.states, s)
+	return len(p.states) - 1, s
+}
+
+func patch(outs []*int, to int) {
+	for _, o := range outs {
+		*o = to
+	}
+}
+
+func (p *regexParser) more() bool { return p.pos < len(p.expr) }
+
+func (p *regexParser) peek() byte { return p.expr[p.pos] }
+
+// alt := concat ('|' concat)*
+func (p *regexParser) alt() (nfaFrag, error) {
+	f, err := p.concat()
+	if err != nil {
+		return f, err
+	}
+	for p.more() && p.peek() == '|' {
+		p.pos++
+		g, err := p.concat()
+		if err != nil {
+			return g, err
+		}
+		i, s := p.state(nfaSplit)
+		s.out, s.out1 = f.start, g.start
+		f = nfaFrag{i, append(f.outs, g.outs...)}
+	}
+	return f, nil
+}
+
+// concat := repeat*, an empty concat is a single epsilon
+func (p *regexParser) concat() (nfaFrag, error) {
+	i, s := p.state(nfaJump)
+	f := nfaFrag{i, []*int{&s.out}}
+	for p.more() && p.peek() != '|' && p.peek() != ')' {
+		g, err := p.repeat()
+		if err != nil {
+			return g, err
+		}
+		patch(f.outs, g.start)
+		f.outs = g.outs
+	}
+	return f, nil
+}
+
+// repeat := atom ('*' | '+' | '?')*
+func (p *regexParser) repeat() (nfaFrag, error) {
+	f, err := p.atom()
+	if err != nil {
+		return f, err
+	}
+	for p.more() {
+		i, s := p.state(nfaSplit)
+		s.out = f.start
+		switch p.peek() {
+		case '*':
+			patch(f.outs, i)
+			f = nfaFrag{i, []*int{&s.out1}}
+		case '+':
+			patch(f.outs, i)
+			f = nfaFrag{f.start, []*int{&s.out1}}
+		case '?':
+			f = nfaFrag{i, append(f.outs, &s.out1)}
+		default:
+			p.states = p.states[:i]
+			return f, nil
+		}
+		p.pos++
+	}
+	return f, nil
+}
+
+func (p *regexParser) atom() (nfaFrag, error) {
+	c := p.peek()
+	p.pos++
+	switch c {
+	case '(':
+		f, err := p.alt()
+		if err != nil {
+			return f, err
+		}
+		if !p.more() || p.peek() != ')' {
+			return f, p.errorf("missing )")
+		}
+		p.pos++
+		return f, nil
+	case '*', '+', '?':
+		return nfaFrag{}, p.errorf("nothing to repeat")
+	case '^', '$':
+		op := nfaBegin
+		if c == '$' {
+			op = nfaEnd
+		}
+		i, s := p.state(op)
+		return nfaFrag{i, []*int{&s.out}}, nil
+	}
+
+	i, s := p.state(nfaByte)
+	switch c {
+	case '.':
+		s.set.add('\n')
+		s.set.negate()
+	case '[':
+		set, err := p.class()
+		if err != nil {
+			return nfaFrag{}, err
+		}
+		s.set = set
+	case '\\':
+		set, err := p.escape()
+		if err != nil {
+			return nfaFrag{}, err
+		}
+		s.set = set
+	default:
+		s.set.add(c)
+	}
+	return nfaFrag{i, []*int{&s.out}}, nil
+}
+
+func (p *regexParser) escape() (byteSet, error) {
+	set := byteSet{}
+	if !p.more() {
+		return set, p.errorf("trailing backslash")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'd', 'D':
+		set.addRange('0', '9')
+	case 'w', 'W':
+		set.addRange('0', '9')
+		set.addRange('a', 'z')
+		set.addRange('A', 'Z')
+		set.add('_')
+	case 's', 'S':
+		for _, c := range []byte(" \t\n\r\f\v") {
+			set.add(c)
+		}
+	case 'n':
+		set.add('\n')
+	case 't':
+		set.add('\t')
+	case 'r':
+		set.add('\r')
+	default:
+		set.add(c)
+		return set, nil
+	}
+	if c >= 'A' && c <= 'Z' {
+		set.negate()
+	}
+	return set, nil
+}
+
+// class := '[' '^'? (char | char '-' char | escape)+ ']'
+func (p *regexParser) class() (byteSet, error) {
+	set, neg := byteSet{}, false
+	if p.more() && p.peek() == '^' {
+		neg = true
+		p.pos++
+	}
+	first := true
+	for {
+		if !p.more() {
+			return set, p.errorf("missing ]")
+		}
+		c := p.peek()
+		p.pos++
+		if c == ']' && !first {
+			break
+		}
+		first = false
+		if c == '\\' {
+			esc, err := p.escape()
+			if err != nil {
+				return set, err
+			}
+			set.union(&esc)
+			continue
+		}
+		if p.pos+1 < len(p.expr) && p.peek() == '-' && p.expr[p.pos+1] != ']' {
+			hi := p.expr[p.pos+1]
+			if hi < c {
+				return set, p.errorf("invalid range %c-%c", c, hi)
+			}
+			set.addRange(c, hi)
+			p.pos += 2
+			continue
+		}
+		set.add(c)
+	}
+	if neg {
+		set.negate()
+	}
+	return set, nil
+}
+
+// cached dfa state, one per distinct set of nfa states, only the
+// transitions are filled in later so they are read without a lock
+type dfaState struct {
+	nfa   []int
+	next  [256]atomic.Pointer[dfaState]
+	match bool
+}
+
+const maxDFAStates = 4096
+
+// Regex is a Thompson NFA compiled from a small regular expression
+// syntax: concatenation, '|', '*', '+', '?', '.', groups, classes
+// like [a-z] or [^0-9], the \d \w \s escapes and the ^ $ anchors.
+// Matching works on bytes and finds a match anywhere in the input,
+// running the NFA through a lazily built and bounded DFA cache.
+// Like regexp.Regexp, a Regex is safe for concurrent use, matches
+// share the cache and only lock it to add states.
+type Regex struct {
+	expr   string
+	states []*nfaState
+	start  int
+	mu     sync.Mutex
+	cache  map[string]*dfaState
+}
+
+func CompileRegex(expr string) (*Regex, error) {
+	p := &regexParser{expr: expr}
+	f, err := p.alt()
+	if err != nil {
+		return nil, err
+	}
+	if p.more() {
+		return nil, p.errorf("unexpected )")
+	}
+	i, _ := p.state(nfaMatch)
+	patch(f.outs, i)
+	return &Regex{expr: expr, states: p.states, start: f.start}, nil
+}
+
+func (re *Regex) String() string { return re.expr }
+
+// add the epsilon closure of state i, zero width assertions are
+// followed when they hold, pending $ states are kept so the end
+// of input can still be checked later
+func (re *Regex) addState(list []int, seen []bool, i int, atStart, atEnd bool) []int {
+	if seen[i] {
+		return list
+	}
+	seen[i] = true
+	s := re.states[i]
+	switch s.op {
+	case nfaSplit:
+		list = re.addState(list, seen, s.out, atStart, atEnd)
+		return re.addState(list, seen, s.out1, atStart, atEnd)
+	case nfaJump:
+		return re.addState(list, seen, s.out, atStart, atEnd)
+	case nfaBegin:
+		if atStart {
+			return re.addState(list, seen, s.out, atStart, atEnd)
+		}
+		return list
+	case nfaEnd:
+		if atEnd {
+			return re.addState(list, seen, s.out, atStart, atEnd)
+		}
+	}
+	return append(list, i)
+}
+
+func (re *Regex) step(list []int, c byte) []int {
+	seen := make([]bool, len(re.states))
+	next := []int{}
+	for _, i := range list {
+		if s := re.states[i]; s.op == nfaByte && s.set.has(c) {
+			next = re.addState(next, seen, s.out, false, false)
+		}
+	}
+	// unanchored search, a match may also start after c
+	return re.addState(next, seen, re.start, false, false)
+}
+
+func (re *Regex) matched(list []int) bool {
+	for _, i := range list {
+		if re.states[i].op == nfaMatch {
+			return true
+		}
+	}
+	return false
+}
+
+// follow the pending $ states at the end of input
+func (re *Regex) matchedAtEnd(list []int, atStart bool) bool {
+	seen := make([]bool, len(re.states))
+	final := []int{}
+	for _, i := range list {
+		final = re.addState(final, seen, i, atStart, true)
+	}
+	return re.matched(final)
+}
+
+func (re *Regex) initial() []int {
+	return re.addState(nil, make([]bool, len(re.states)), re.start, true, false)
+}
+
+// MatchNFA simulates the NFA directly, tracking the set of active
+// states, linear in the input length without any caching
+func (re *Regex) MatchNFA(s string) bool {
+	list := re.initial()
+	for i := 0; i < len(s); i++ {
+		if re.matched(list) {
+			return true
+		}
+		list = re.step(list, s[i])
+	}
+	return re.matched(list) || re.matchedAtEnd(list, len(s) == 0)
+}
+
+func (re *Regex) dfa(list []int) *dfaState {
+	sorted := append([]int{}, list...)
+	sort.Ints(sorted)
+	sb := strings.Builder{}
+	for _, i := range sorted {
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteByte(',')
+	}
+	key := sb.String()
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	if d, ok := re.cache[key]; ok {
+		return d
+	}
+	if re.cache == nil || len(re.cache) >= maxDFAStates {
+		// start over instead of growing without bound
+		re.cache = map[string]*dfaState{}
+	}
+	d := &dfaState{nfa: sorted, match: re.matched(sorted)}
+	re.cache[key] = d
+	return d
+}
+
+// concurrent matches may build the same transition, all of them
+// store an equivalent state, and states dropped by a cache reset
+// stay valid for the matches still holding them
+func (re *Regex) next(d *dfaState, c byte) *dfaState {
+	n := d.next[c].Load()
+	if n == nil {
+		n = re.dfa(re.step(d.nfa, c))
+		d.next[c].Store(n)
+	}
+	return n
+}
+
+// Match reports whether s contains a match of the regex
+func (re *Regex) Match(s string) bool {
+	d := re.dfa(re.initial())
+	for i := 0; i < len(s) && !d.match; i++ {
+		d = re.next(d, s[i])
+	}
+	return d.match || re.matchedAtEnd(d.nfa, len(s) == 0)
+}
+
+// MatchReader reports whether the stream contains a match, it
+// stops reading at the first match and keeps no input around,
+// so it works on unbounded streams
+func (re *Regex) MatchReader(r io.Reader) (bool, error) {
+	br := bufio.NewReader(r)
+	d := re.dfa(re.initial())
+	n := 0
+	for !d.match {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return re.matchedAtEnd(d.nfa, n == 0), nil
+		}
+		if err != nil {
+			return false, err
+		}
+		d = re.next(d, c)
+		n++
+	}
+	return true, nil
+}
@@ -0,0 +1,111 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"gotest.tools/v3/assert"
+)
+
+func randRegex(r *rand.Rand, depth int) string {
+	atoms := []string{"a", "b", ".", "[ab]", "[^a]", "^", "$", `\d`, "c"}
+	if depth == 0 {
+		return atoms[r.Intn(len(atoms))]
+	}
+	switch r.Intn(5) {
+	case 0:
+		return randRegex(r, depth-1) + randRegex(r, depth-1)
+	case 1:
+		return randRegex(r, depth-1) + "|" + randRegex(r, depth-1)
+	case 2:
+		return "(" + randRegex(r, depth-1) + ")" + []string{"*", "+", "?"}[r.Intn(3)]
+	case 3:
+		return "(" + randRegex(r, depth-1) + ")"
+	}
+	return atoms[r.Intn(len(atoms))]
+}
+
+func TestAlgo_Regex(t *testing.T) {
+	for _, bad := range []string{"(a", "a)", "*a", "[a", `a\`, "[z-a]"} {
+		_, err := algo.CompileRegex(bad)
+		assert.Assert(t, err != nil, bad)
+	}
+
+	re, err := algo.CompileRegex(`^(\d+-)?[a-c]+x*$`)
+	assert.NilError(t, err)
+	assert.Assert(t, re.Match("12-abcxx"))
+	assert.Assert(t, re.Match("cab"))
+	assert.Assert(t, !re.Match("12-"))
+	assert.Assert(t, !re.Match(" cab"))
+
+	re, err = algo.CompileRegex("a|")
+	assert.NilError(t, err)
+	assert.Assert(t, re.Match(""))
+	re, err = algo.CompileRegex("^$")
+	assert.NilError(t, err)
+	assert.Assert(t, re.Match("") && !re.Match("a"))
+
+	// streaming finds a match without reading everything
+	re, err = algo.CompileRegex("error: [0-9]+")
+	assert.NilError(t, err)
+	input := strings.Repeat("ok\n", 100000) + "error: 42\n" + strings.Repeat("ok\n", 100000)
+	ok, err := re.MatchReader(iotest.HalfReader(strings.NewReader(input)))
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	ok, err = re.MatchReader(strings.NewReader(strings.Repeat("ok\n", 1000)))
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 2000; i++ {
+		expr := randRegex(r, 1+r.Intn(4))
+		re, err := algo.CompileRegex(expr)
+		assert.NilError(t, err, expr)
+		std := regexp.MustCompile(expr)
+		for j := 0; j < 10; j++ {
+			s := randText(r, "abc1\n", r.Intn(8))
+			want := std.MatchString(s)
+			assert.Equal(t, re.Match(s), want, "%q on %q", expr, s)
+			assert.Equal(t, re.MatchNFA(s), want, "%q on %q", expr, s)
+			ok, err := re.MatchReader(strings.NewReader(s))
+			assert.NilError(t, err)
+			assert.Equal(t, ok, want, "%q on %q", expr, s)
+		}
+	}
+}
+
+func TestAlgo_RegexConcurrent(t *testing.T) {
+	// the 13th char from the end is an a, more dfa states
+	// than the cache holds so it is also reset while shared
+	expr := "a" + strings.Repeat("[ab]", 12) + "$"
+	re, err := algo.CompileRegex(expr)
+	assert.NilError(t, err)
+	std := regexp.MustCompile(expr)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan string, 8)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < 300; i++ {
+				s := randText(r, "ab", r.Intn(200))
+				if re.Match(s) != std.MatchString(s) {
+					errs <- s
+					return
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+	close(errs)
+	for s := range errs {
+		t.Errorf("%q on %q", expr, s)
+	}
+}
@@ -0,0 +1,16 @@
+package algo
+
+// Searcher finds all, possibly overlapping, occurrences of
+// a compiled pattern, offsets are in bytes
+type Searcher interface {
+	FindAll(s string) []int
+}
+
+var (
+	_ Searcher = (*Pattern)(nil)
+	_ Searcher = (*BoyerMoore)(nil)
+	_ Searcher = (*Horspool)(nil)
+	_ Searcher = (*RabinKarp)(nil)
+	_ Searcher = (*ZSearch)(nil)
+	_ Searcher = (*TwoWay)(nil)
+)
@@ -0,0 +1,107 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+var searchers = []struct {
+	name    string
+	compile func(string) (algo.Searcher, error)
+}{
+	{"KMP", func(p string) (algo.Searcher, error) { return algo.CompilePattern(p) }},
+	{"BoyerMoore", func(p string) (algo.Searcher, error) { return algo.NewBoyerMoore(p) }},
+	{"Horspool", func(p string) (algo.Searcher, error) { return algo.NewHorspool(p) }},
+	{"RabinKarp", func(p string) (algo.Searcher, error) { return algo.NewRabinKarp(p) }},
+	{"Z", func(p string) (algo.Searcher, error) { return algo.NewZSearch(p) }},
+	{"TwoWay", func(p string) (algo.Searcher, error) { return algo.NewTwoWay(p) }},
+}
+
+func randText(r *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+func TestAlgo_Searchers(t *testing.T) {
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for _, sc := range searchers {
+		_, err := sc.compile("")
+		assert.Error(t, err, "pattern must not be empty")
+
+		s, err := sc.compile("aa")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, s.FindAll("aaaa"), []int{0, 1, 2})
+		assert.DeepEqual(t, s.FindAll("a"), []int{})
+
+		for i := 0; i < 500; i++ {
+			pat := randText(r, "ab", 1+r.Intn(8))
+			text := randText(r, "ab", r.Intn(200))
+			s, err := sc.compile(pat)
+			assert.NilError(t, err)
+			assert.Assert(t, is.DeepEqual(s.FindAll(text), naiveIndex(text, pat, true)),
+				"%s: pattern %q text %q", sc.name, pat, text)
+		}
+	}
+}
+
+func FuzzSearchers(f *testing.F) {
+	f.Add("abababab", "aba")
+	f.Add("hel你好lo,你好", "你好")
+	f.Add("GCATCGCAGAGAGTATACAGTACG", "GCAGAGAG")
+	f.Fuzz(func(t *testing.T, text, pat string) {
+		for _, sc := range searchers {
+			s, err := sc.compile(pat)
+			if err != nil {
+				return
+			}
+			assert.Assert(t, is.DeepEqual(s.FindAll(text), naiveIndex(text, pat, true)), sc.name)
+		}
+	})
+}
+
+var words = strings.Fields(`the quick brown fox jumps over a lazy dog while
+	searching for patterns in long natural language texts with many short
+	common words and some longer uncommon vocabulary appearing rarely`)
+
+func naturalText(r *rand.Rand, n int) string {
+	sb := strings.Builder{}
+	for sb.Len() < n {
+		sb.WriteString(words[r.Intn(len(words))])
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
+
+func BenchmarkSearch(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	natural, dna := naturalText(r, 1<<20), randText(r, "ACGT", 1<<20)
+	inputs := []struct {
+		name, text string
+		pats       []string
+	}{
+		{"natural", natural, []string{"fox", "uncommon vocabulary", "language texts with many short"}},
+		{"dna", dna, []string{"ACG", dna[1000:1016], dna[5000:5064]}},
+	}
+	for _, in := range inputs {
+		for _, pat := range in.pats {
+			for _, sc := range searchers {
+				s, _ := sc.compile(pat)
+				b.Run(fmt.Sprintf("%s/%s/%d", in.name, sc.name, len(pat)), func(b *testing.B) {
+					b.SetBytes(int64(len(in.text)))
+					for i := 0; i < b.N; i++ {
+						s.FindAll(in.text)
+					}
+				})
+			}
+		}
+	}
+}
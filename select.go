@@ -0,0 +1,111 @@
+package algo
+
+import (
+	"math/bits"
+	"math/rand"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Partition3way is the partition step of Qsort3way around the
+// pivot s[p], on return s[:lt] < pivot, s[lt:gt] == pivot and
+// s[gt:] > pivot
+func Partition3way[T constraints.Ordered](s []T, p int) (lt, gt int) {
+	return Partition3wayFunc(s, p, cmpOrdered[T])
+}
+
+func Partition3wayFunc[T any](s []T, p int, cmp func(a, b T) int) (lt, gt int) {
+	return (&qsorter[T]{cmp: cmp}).partitionAt(s, p)
+}
+
+// quickselect, only the side holding position n is partitioned
+// further, an equal range around n ends the search early
+func (q *qsorter[T]) nth(s []T, n int) {
+	for len(s) > 1 {
+		i, k := q.partition(s)
+		if n < i {
+			s = s[:i]
+		} else if n >= k {
+			s, n = s[k:], n-k
+		} else {
+			return
+		}
+	}
+}
+
+// NthElement reorders s so s[n] is the element a full sort would
+// put there, with no larger elements before and no smaller ones
+// after it, in expected O(n) time. It panics if n is out of range.
+func NthElement[T constraints.Ordered](s []T, n int) {
+	NthElementFunc(s, n, cmpOrdered[T])
+}
+
+func NthElementFunc[T any](s []T, n int, cmp func(a, b T) int) {
+	NthElementFuncWith(s, n, cmp, QsortOptions{})
+}
+
+// with PivotMedianOfMedians the selection is O(n) in the worst case
+func NthElementFuncWith[T any](s []T, n int, cmp func(a, b T) int, opts QsortOptions) {
+	if n < 0 || n >= len(s) {
+		panic("algo: nth element index out of range")
+	}
+	q := &qsorter[T]{cmp: cmp, pivot: opts.Pivot}
+	if opts.Source != nil {
+		q.rnd = rand.New(opts.Source)
+	}
+	q.nth(s, n)
+}
+
+// Select returns the k-th smallest element, counting from 0,
+// reordering s as NthElement does
+func Select[T constraints.Ordered](s []T, k int) T {
+	NthElement(s, k)
+	return s[k]
+}
+
+func SelectFunc[T any](s []T, k int, cmp func(a, b T) int) T {
+	NthElementFunc(s, k, cmp)
+	return s[k]
+}
+
+func SelectWith[T constraints.Ordered](s []T, k int, opts QsortOptions) T {
+	NthElementFuncWith(s, k, cmpOrdered[T], opts)
+	return s[k]
+}
+
+// PartialSort puts the k smallest elements of s in order
+// at its front, the order of the rest is unspecified
+func PartialSort[T constraints.Ordered](s []T, k int) {
+	PartialSortFunc(s, k, cmpOrdered[T])
+}
+
+func PartialSortFunc[T any](s []T, k int, cmp func(a, b T) int) {
+	if k <= 0 {
+		return
+	}
+	if k < len(s) {
+		NthElementFunc(s, k-1, cmp)
+	} else {
+		k = len(s)
+	}
+	q := &qsorter[T]{cmp: cmp}
+	q.introsort(s[:k], 2*bits.Len(uint(k)))
+}
+
+// TopK returns the k largest elements of s, largest first,
+// the result is the front of the reordered s, not a copy
+func TopK[T constraints.Ordered](s []T, k int) []T {
+	return TopKFunc(s, k, cmpOrdered[T])
+}
+
+func TopKFunc[T any](s []T, k int, cmp func(a, b T) int) []T {
+	if k > len(s) {
+		k = len(s)
+	}
+	if k <= 0 {
+		return s[:0]
+	}
+	desc := func(a, b T) int { return cmp(b, a) }
+	PartialSortFunc(s, k, desc)
+	return s[:k]
+}
@@ -0,0 +1,66 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_Select(t *testing.T) {
+	s := []int{5, 1, 5, 3, 5, 9, 0}
+	lt, gt := algo.Partition3way(s, 0)
+	assert.Equal(t, lt, 3)
+	assert.Equal(t, gt, 6)
+	for i, v := range s {
+		assert.Assert(t, (i < lt && v < 5) || (i >= gt && v > 5) || (i >= lt && i < gt && v == 5))
+	}
+
+	for _, n := range []int{1, 2, 7, 100, 1000} {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = rand.Intn(n/2 + 1)
+		}
+		sorted := append([]int{}, s...)
+		sort.Ints(sorted)
+
+		for _, k := range []int{0, n / 2, n - 1} {
+			assert.Equal(t, algo.Select(append([]int{}, s...), k), sorted[k])
+			for _, pivot := range []algo.PivotStrategy{algo.PivotNinther, algo.PivotMedianOfMedians} {
+				assert.Equal(t, algo.SelectWith(append([]int{}, s...), k,
+					algo.QsortOptions{Pivot: pivot}), sorted[k])
+			}
+
+			c := append([]int{}, s...)
+			algo.NthElement(c, k)
+			for i := range c {
+				assert.Assert(t, (i <= k && c[i] <= c[k]) || (i >= k && c[i] >= c[k]))
+			}
+
+			c = append([]int{}, s...)
+			algo.PartialSort(c, k)
+			assert.DeepEqual(t, c[:k], sorted[:k])
+
+			c = append([]int{}, s...)
+			top := algo.TopK(c, k)
+			for i := 0; i < k; i++ {
+				assert.Equal(t, top[i], sorted[n-1-i])
+			}
+		}
+	}
+
+	people := []person{{"a", 30}, {"b", 10}, {"c", 20}}
+	byAge := func(a, b person) int { return a.age - b.age }
+	assert.Equal(t, algo.SelectFunc(people, 1, byAge).name, "c")
+	assert.Equal(t, algo.TopKFunc(people, 1, byAge)[0].name, "a")
+	assert.DeepEqual(t, algo.TopK([]int{1, 2}, 5), []int{2, 1})
+	assert.DeepEqual(t, algo.TopK([]int{1, 2}, 0), []int{})
+
+	// median of medians also drives a worst case n log n sort
+	big := rand.Perm(10000)
+	algo.Qsort3wayWith(big, algo.QsortOptions{Pivot: algo.PivotMedianOfMedians})
+	assert.Assert(t, slices.IsSorted(big))
+}
@@ -0,0 +1,115 @@
+package algo
+
+import "math/rand"
+
+// radix of byte strings
+const radix = 256
+
+// byte at d, or -1 past the end so shorter keys sort first
+func charAt(s string, d int) int {
+	if d < len(s) {
+		return int(s[d])
+	}
+	return -1
+}
+
+// keys sharing their first d bytes
+func insertionFrom(s []string, d int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j][d:] < s[j-1][d:]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// Qsort3wayString is Bentley-Sedgewick multikey quicksort, it
+// 3 way partitions on the byte at depth d and only advances to
+// d+1 inside the equal part, so long common prefixes are
+// scanned once rather than in every full string comparison
+func Qsort3wayString(s []string) {
+	qsort3wayString(s, 0)
+}
+
+func qsort3wayString(s []string, d int) {
+	for len(s) > insertionCutoff {
+		n := rand.Intn(len(s))
+		s[0], s[n] = s[n], s[0]
+		v := charAt(s[0], d)
+		lt, i, gt := 0, 1, len(s)
+		for i < gt {
+			c := charAt(s[i], d)
+			if c < v {
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			} else if c > v {
+				gt--
+				s[i], s[gt] = s[gt], s[i]
+			} else {
+				i++
+			}
+		}
+		qsort3wayString(s[:lt], d)
+		qsort3wayString(s[gt:], d)
+		if v < 0 {
+			return
+		}
+		s, d = s[lt:gt], d+1
+	}
+	insertionFrom(s, d)
+}
+
+// LSDSort sorts keys by their first w bytes, with key indexed
+// counting from the last byte to the first, each pass stable.
+// Keys shorter than w sort as if padded below any byte value,
+// so keys of exactly w bytes end up fully sorted.
+func LSDSort(s []string, w int) {
+	aux := make([]string, len(s))
+	for d := w - 1; d >= 0; d-- {
+		count := [radix + 2]int{}
+		for _, k := range s {
+			count[charAt(k, d)+2]++
+		}
+		for r := 0; r < radix+1; r++ {
+			count[r+1] += count[r]
+		}
+		for _, k := range s {
+			c := charAt(k, d) + 1
+			aux[count[c]] = k
+			count[c]++
+		}
+		copy(s, aux)
+	}
+}
+
+// MSDSort sorts variable length keys with key indexed counting
+// on the first byte, then recursively on each byte bucket
+func MSDSort(s []string) {
+	msdSort(s, make([]string, len(s)), 0)
+}
+
+func msdSort(s, aux []string, d int) {
+	if len(s) <= insertionCutoff {
+		insertionFrom(s, d)
+		return
+	}
+	count := [radix + 2]int{}
+	for _, k := range s {
+		count[charAt(k, d)+2]++
+	}
+	for r := 0; r < radix+1; r++ {
+		count[r+1] += count[r]
+	}
+	start := count
+	for _, k := range s {
+		c := charAt(k, d) + 1
+		aux[count[c]] = k
+		count[c]++
+	}
+	copy(s, aux[:len(s)])
+
+	// bucket 0 holds keys ending at d, they are all equal
+	for r := 1; r < radix+1; r++ {
+		msdSort(s[start[r]:start[r+1]], aux, d+1)
+	}
+}
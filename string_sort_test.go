@@ -0,0 +1,57 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_StringSort(t *testing.T) {
+	keys := urlKeys(2000)
+	for i := 0; i < 500; i++ {
+		keys = append(keys, randText(rand.New(rand.NewSource(int64(i))), "ab\xff", rand.Intn(6)))
+	}
+	expected := append([]string{}, keys...)
+	sort.Strings(expected)
+
+	s := append([]string{}, keys...)
+	algo.Qsort3wayString(s)
+	assert.DeepEqual(t, s, expected)
+
+	s = append([]string{}, keys...)
+	algo.MSDSort(s)
+	assert.DeepEqual(t, s, expected)
+
+	plates := []string{}
+	for i := 0; i < 2000; i++ {
+		plates = append(plates, randText(rand.New(rand.NewSource(int64(i))), "0123ABC", 7))
+	}
+	expected = append([]string{}, plates...)
+	sort.Strings(expected)
+	algo.LSDSort(plates, 7)
+	assert.DeepEqual(t, plates, expected)
+
+	// stable on the first w bytes only
+	s = []string{"ab2", "aa9", "ab1", "a"}
+	algo.LSDSort(s, 2)
+	assert.DeepEqual(t, s, []string{"a", "aa9", "ab2", "ab1"})
+}
+
+func BenchmarkStringSort_Strings(b *testing.B) {
+	benchmarkSort(b, urlKeys(100000), sort.Strings)
+}
+
+func BenchmarkStringSort_Qsort3wayOrdered(b *testing.B) {
+	benchmarkSort(b, urlKeys(100000), algo.Qsort3wayOrdered[string])
+}
+
+func BenchmarkStringSort_Qsort3wayString(b *testing.B) {
+	benchmarkSort(b, urlKeys(100000), algo.Qsort3wayString)
+}
+
+func BenchmarkStringSort_MSD(b *testing.B) {
+	benchmarkSort(b, urlKeys(100000), algo.MSDSort)
+}
@@ -0,0 +1,251 @@
+package algo
+
+import "sort"
+
+// suffix array of s with symbols in [0, upper], built with SA-IS:
+// sort the LMS substrings by induced sorting, name them, recurse
+// on the reduced string if names are not unique, then induce the
+// full order from the sorted LMS suffixes
+func sais(s []int, upper int) []int {
+	n := len(s)
+	switch n {
+	case 0:
+		return []int{}
+	case 1:
+		return []int{0}
+	case 2:
+		if s[0] < s[1] {
+			return []int{0, 1}
+		}
+		return []int{1, 0}
+	}
+
+	sa := make([]int, n)
+	ls := make([]bool, n) // S type suffixes, smaller than the next one
+	for i := n - 2; i >= 0; i-- {
+		if s[i] == s[i+1] {
+			ls[i] = ls[i+1]
+		} else {
+			ls[i] = s[i] < s[i+1]
+		}
+	}
+
+	// bucket boundaries, L type suffixes fill a bucket from the
+	// front, S type ones from sumS onward
+	sumL, sumS := make([]int, upper+1), make([]int, upper+1)
+	for i := 0; i < n; i++ {
+		if !ls[i] {
+			sumS[s[i]]++
+		} else {
+			sumL[s[i]+1]++
+		}
+	}
+	for i := 0; i <= upper; i++ {
+		sumS[i] += sumL[i]
+		if i < upper {
+			sumL[i+1] += sumS[i]
+		}
+	}
+
+	buf := make([]int, upper+1)
+	induce := func(lms []int) {
+		for i := range sa {
+			sa[i] = -1
+		}
+		copy(buf, sumS)
+		for _, d := range lms {
+			if d == n {
+				continue
+			}
+			sa[buf[s[d]]] = d
+			buf[s[d]]++
+		}
+		copy(buf, sumL)
+		sa[buf[s[n-1]]] = n - 1
+		buf[s[n-1]]++
+		for i := 0; i < n; i++ {
+			v := sa[i]
+			if v >= 1 && !ls[v-1] {
+				sa[buf[s[v-1]]] = v - 1
+				buf[s[v-1]]++
+			}
+		}
+		copy(buf, sumL)
+		for i := n - 1; i >= 0; i-- {
+			v := sa[i]
+			if v >= 1 && ls[v-1] {
+				buf[s[v-1]+1]--
+				sa[buf[s[v-1]+1]] = v - 1
+			}
+		}
+	}
+
+	lmsMap := make([]int, n+1)
+	for i := range lmsMap {
+		lmsMap[i] = -1
+	}
+	lms := []int{}
+	for i := 1; i < n; i++ {
+		if !ls[i-1] && ls[i] {
+			lmsMap[i] = len(lms)
+			lms = append(lms, i)
+		}
+	}
+	m := len(lms)
+	induce(lms)
+	if m == 0 {
+		return sa
+	}
+
+	sorted := make([]int, 0, m)
+	for _, v := range sa {
+		if lmsMap[v] != -1 {
+			sorted = append(sorted, v)
+		}
+	}
+	rec, recUpper := make([]int, m), 0
+	rec[lmsMap[sorted[0]]] = 0
+	for i := 1; i < m; i++ {
+		l, r := sorted[i-1], sorted[i]
+		endL, endR := n, n
+		if lmsMap[l]+1 < m {
+			endL = lms[lmsMap[l]+1]
+		}
+		if lmsMap[r]+1 < m {
+			endR = lms[lmsMap[r]+1]
+		}
+		same := endL-l == endR-r
+		if same {
+			for l < endL && s[l] == s[r] {
+				l++
+				r++
+			}
+			if l == n || s[l] != s[r] {
+				same = false
+			}
+		}
+		if !same {
+			recUpper++
+		}
+		rec[lmsMap[sorted[i]]] = recUpper
+	}
+
+	recSA := sais(rec, recUpper)
+	for i := range sorted {
+		sorted[i] = lms[recSA[i]]
+	}
+	induce(sorted)
+	return sa
+}
+
+// Kasai's algorithm, lcp[i] is the longest common prefix of the
+// suffixes sa[i-1] and sa[i], lcp[0] is 0. Going through suffixes
+// in text order the lcp drops by at most one per step.
+func kasai(s, sa []int) []int {
+	n := len(s)
+	rank, lcp := make([]int, n), make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+	h := 0
+	for i := 0; i < n; i++ {
+		if h > 0 {
+			h--
+		}
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+	}
+	return lcp
+}
+
+func byteSymbols(s string) []int {
+	syms := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		syms[i] = int(s[i])
+	}
+	return syms
+}
+
+// SuffixArray indexes all suffixes of a text in sorted order
+// for substring queries, positions are byte offsets
+type SuffixArray struct {
+	text string
+	sa   []int
+	lcp  []int
+}
+
+func NewSuffixArray(text string) *SuffixArray {
+	syms := byteSymbols(text)
+	sa := sais(syms, 255)
+	return &SuffixArray{text: text, sa: sa, lcp: kasai(syms, sa)}
+}
+
+// start offsets of the suffixes in sorted order
+func (s *SuffixArray) Index() []int { return s.sa }
+
+func (s *SuffixArray) LCP() []int { return s.lcp }
+
+// range of suffixes in sa starting with pattern
+func (s *SuffixArray) bounds(pattern string) (int, int) {
+	prefix := func(i int) string {
+		suf := s.text[s.sa[i]:]
+		if len(suf) > len(pattern) {
+			suf = suf[:len(pattern)]
+		}
+		return suf
+	}
+	lo := sort.Search(len(s.sa), func(i int) bool { return prefix(i) >= pattern })
+	hi := sort.Search(len(s.sa), func(i int) bool { return prefix(i) > pattern })
+	return lo, hi
+}
+
+func (s *SuffixArray) Count(pattern string) int {
+	lo, hi := s.bounds(pattern)
+	return hi - lo
+}
+
+// offsets of all occurrences of pattern in ascending order
+func (s *SuffixArray) Locate(pattern string) []int {
+	lo, hi := s.bounds(pattern)
+	pos := append([]int{}, s.sa[lo:hi]...)
+	sort.Ints(pos)
+	return pos
+}
+
+// longest substring occurring at least twice, occurrences may overlap
+func (s *SuffixArray) LongestRepeatedSubstring() string {
+	best, at := 0, 0
+	for i, l := range s.lcp {
+		if l > best {
+			best, at = l, s.sa[i]
+		}
+	}
+	return s.text[at : at+best]
+}
+
+// LongestCommonSubstring indexes a, a separator outside the
+// byte range and b, the answer is the longest lcp between
+// adjacent suffixes coming from different strings
+func LongestCommonSubstring(a, b string) string {
+	syms := append(append(byteSymbols(a), 256), byteSymbols(b)...)
+	sa := sais(syms, 256)
+	lcp := kasai(syms, sa)
+	best, at := 0, 0
+	for i := 1; i < len(sa); i++ {
+		if (sa[i-1] < len(a)) != (sa[i] < len(a)) && lcp[i] > best {
+			best, at = lcp[i], sa[i]
+		}
+	}
+	if at > len(a) {
+		at -= len(a) + 1
+		return b[at : at+best]
+	}
+	return a[at : at+best]
+}
@@ -0,0 +1,57 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func naiveSuffixArray(s string) []int {
+	sa := make([]int, len(s))
+	for i := range sa {
+		sa[i] = i
+	}
+	sort.Slice(sa, func(i, j int) bool { return s[sa[i]:] < s[sa[j]:] })
+	return sa
+}
+
+func TestAlgo_SuffixArray(t *testing.T) {
+	sa := algo.NewSuffixArray("banana")
+	assert.DeepEqual(t, sa.Index(), []int{5, 3, 1, 0, 4, 2})
+	assert.DeepEqual(t, sa.LCP(), []int{0, 1, 3, 0, 0, 2})
+	assert.Equal(t, sa.Count("ana"), 2)
+	assert.Equal(t, sa.Count("nab"), 0)
+	assert.Equal(t, sa.Count(""), 6)
+	assert.DeepEqual(t, sa.Locate("a"), []int{1, 3, 5})
+	assert.DeepEqual(t, sa.Locate("x"), []int{})
+	assert.Equal(t, sa.LongestRepeatedSubstring(), "ana")
+	assert.Equal(t, algo.NewSuffixArray("").LongestRepeatedSubstring(), "")
+	assert.Equal(t, algo.LongestCommonSubstring("xabcdey", "zzbcdeq"), "bcde")
+	assert.Equal(t, algo.LongestCommonSubstring("abc", "xyz"), "")
+
+	for i := 0; i < 200; i++ {
+		text := randText(rand.New(rand.NewSource(int64(i))), "abc"[:1+i%3], rand.Intn(300))
+		sa := algo.NewSuffixArray(text)
+		assert.DeepEqual(t, sa.Index(), naiveSuffixArray(text))
+		for _, p := range []string{"a", "ab", "cab", "aaa"} {
+			assert.Equal(t, sa.Count(p), len(naiveIndex(text, p, true)))
+			assert.DeepEqual(t, sa.Locate(p), naiveIndex(text, p, true))
+		}
+		lrs := sa.LongestRepeatedSubstring()
+		assert.Assert(t, len(naiveIndex(text, lrs, true)) >= 2 || text == "")
+		for j := 0; j+len(lrs)+1 <= len(text); j++ {
+			assert.Assert(t, len(naiveIndex(text, text[j:j+len(lrs)+1], true)) == 1)
+		}
+
+		other := randText(rand.New(rand.NewSource(int64(-i))), "abc", rand.Intn(50))
+		lcs := algo.LongestCommonSubstring(text, other)
+		assert.Assert(t, strings.Contains(text, lcs) && strings.Contains(other, lcs))
+		for j := 0; j+len(lcs)+1 <= len(other); j++ {
+			assert.Assert(t, !strings.Contains(text, other[j:j+len(lcs)+1]))
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package algo
+
+// each state is a class of substrings sharing the same end
+// positions, len is the longest of them, link the state of the
+// longest suffix falling in another class, pos the end of the
+// first occurrence
+type samState struct {
+	len  int
+	link int
+	pos  int
+	next map[byte]int
+}
+
+// SuffixAutomaton is the minimal automaton accepting all
+// substrings of a text, built online one byte at a time
+type SuffixAutomaton struct {
+	text   []byte
+	states []samState
+	last   int
+}
+
+func NewSuffixAutomaton(s string) *SuffixAutomaton {
+	a := &SuffixAutomaton{states: []samState{{link: -1, pos: -1, next: map[byte]int{}}}}
+	a.Append(s)
+	return a
+}
+
+func (a *SuffixAutomaton) Append(s string) {
+	for i := 0; i < len(s); i++ {
+		a.Extend(s[i])
+	}
+}
+
+func (a *SuffixAutomaton) Extend(c byte) {
+	a.text = append(a.text, c)
+	cur := len(a.states)
+	a.states = append(a.states, samState{
+		len: a.states[a.last].len + 1, pos: len(a.text) - 1, next: map[byte]int{}})
+
+	p := a.last
+	for p != -1 {
+		if _, ok := a.states[p].next[c]; ok {
+			break
+		}
+		a.states[p].next[c] = cur
+		p = a.states[p].link
+	}
+	a.last = cur
+	if p == -1 {
+		return
+	}
+
+	q := a.states[p].next[c]
+	if a.states[p].len+1 == a.states[q].len {
+		a.states[cur].link = q
+		return
+	}
+
+	// q holds longer strings too, split off the short ones
+	clone := len(a.states)
+	next := map[byte]int{}
+	for k, v := range a.states[q].next {
+		next[k] = v
+	}
+	a.states = append(a.states, samState{
+		len: a.states[p].len + 1, link: a.states[q].link, pos: a.states[q].pos, next: next})
+	for p != -1 && a.states[p].next[c] == q {
+		a.states[p].next[c] = clone
+		p = a.states[p].link
+	}
+	a.states[q].link = clone
+	a.states[cur].link = clone
+}
+
+func (a *SuffixAutomaton) States() int { return len(a.states) }
+
+func (a *SuffixAutomaton) Contains(sub string) bool {
+	v := 0
+	for i := 0; i < len(sub); i++ {
+		next, ok := a.states[v].next[sub[i]]
+		if !ok {
+			return false
+		}
+		v = next
+	}
+	return true
+}
+
+// number of distinct non empty substrings
+func (a *SuffixAutomaton) DistinctSubstrings() int {
+	cnt := 0
+	for _, st := range a.states[1:] {
+		cnt += st.len - a.states[st.link].len
+	}
+	return cnt
+}
+
+// longest match of a prefix of every suffix of t, recorded per state
+func (a *SuffixAutomaton) matchLens(t string) []int {
+	lens := make([]int, len(a.states))
+	v, l := 0, 0
+	for i := 0; i < len(t); i++ {
+		next, ok := a.states[v].next[t[i]]
+		for !ok && v != 0 {
+			v = a.states[v].link
+			l = a.states[v].len
+			next, ok = a.states[v].next[t[i]]
+		}
+		if ok {
+			v = next
+			l++
+		}
+		if l > lens[v] {
+			lens[v] = l
+		}
+	}
+
+	// a match in a state also matches the suffixes in its link
+	order := a.byLen()
+	for i := len(order) - 1; i > 0; i-- {
+		v := order[i]
+		link := a.states[v].link
+		m := lens[v]
+		if m > a.states[link].len {
+			m = a.states[link].len
+		}
+		if m > lens[link] {
+			lens[link] = m
+		}
+	}
+	return lens
+}
+
+// states sorted by len with counting sort
+func (a *SuffixAutomaton) byLen() []int {
+	cnt := make([]int, len(a.text)+2)
+	for _, st := range a.states {
+		cnt[st.len+1]++
+	}
+	for i := 1; i < len(cnt); i++ {
+		cnt[i] += cnt[i-1]
+	}
+	order := make([]int, len(a.states))
+	for v, st := range a.states {
+		order[cnt[st.len]] = v
+		cnt[st.len]++
+	}
+	return order
+}
+
+// LongestCommonSubstringOf finds the longest string occurring in
+// all strs, matching every other string against the automaton of
+// the first one
+func LongestCommonSubstringOf(strs ...string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	a := NewSuffixAutomaton(strs[0])
+	best := make([]int, len(a.states))
+	for v, st := range a.states {
+		best[v] = st.len
+	}
+	for _, t := range strs[1:] {
+		for v, l := range a.matchLens(t) {
+			if l < best[v] {
+				best[v] = l
+			}
+		}
+	}
+
+	l, end := 0, 0
+	for v, b := range best {
+		if b > l {
+			l, end = b, a.states[v].pos+1
+		}
+	}
+	return strs[0][end-l : end]
+}
@@ -0,0 +1,48 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_SuffixAutomaton(t *testing.T) {
+	a := algo.NewSuffixAutomaton("abcbc")
+	assert.Equal(t, a.DistinctSubstrings(), 12)
+	assert.Assert(t, a.Contains("cbc"))
+	assert.Assert(t, a.Contains(""))
+	assert.Assert(t, !a.Contains("ac"))
+	a.Extend('a')
+	assert.Assert(t, a.Contains("bca"))
+
+	assert.Equal(t, algo.LongestCommonSubstringOf("xabcdey", "zzbcdeq", "bcdxbcd"), "bcd")
+	assert.Equal(t, algo.LongestCommonSubstringOf("abc"), "abc")
+	assert.Equal(t, algo.LongestCommonSubstringOf("abc", "xyz"), "")
+	assert.Equal(t, algo.LongestCommonSubstringOf(), "")
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 100; i++ {
+		text := randText(r, "abc", r.Intn(60))
+		a := algo.NewSuffixAutomaton(text)
+		subs := map[string]bool{}
+		for j := 0; j < len(text); j++ {
+			for k := j + 1; k <= len(text); k++ {
+				subs[text[j:k]] = true
+			}
+		}
+		assert.Equal(t, a.DistinctSubstrings(), len(subs))
+		assert.Assert(t, a.States() <= 2*len(text)+1)
+		for j := 0; j < 20; j++ {
+			sub := randText(r, "abc", 1+r.Intn(5))
+			assert.Equal(t, a.Contains(sub), strings.Contains(text, sub))
+		}
+
+		other := randText(r, "abc", r.Intn(40))
+		lcs := algo.LongestCommonSubstringOf(text, other)
+		assert.Equal(t, len(lcs), len(algo.LongestCommonSubstring(text, other)))
+		assert.Assert(t, strings.Contains(text, lcs) && strings.Contains(other, lcs))
+	}
+}
@@ -0,0 +1,174 @@
+package algo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// edge into the node is text[start:*end+1], all leaves share
+// the same end pointer so they grow together in every phase
+type stNode struct {
+	start int
+	end   *int
+	link  *stNode
+	next  map[int]*stNode
+}
+
+// unique terminal symbol, outside the byte range
+const stTerminal = 256
+
+// SuffixTree is a compressed trie of all suffixes of a text,
+// built in linear time with Ukkonen's algorithm
+type SuffixTree struct {
+	text []int
+	root *stNode
+}
+
+func NewSuffixTree(s string) *SuffixTree {
+	t := &SuffixTree{text: append(byteSymbols(s), stTerminal)}
+	rootEnd, leafEnd := -1, -1
+	t.root = &stNode{start: -1, end: &rootEnd, next: map[int]*stNode{}}
+
+	// active point: where the next suffix to insert ends
+	activeNode, activeEdge, activeLen := t.root, 0, 0
+	remainder := 0
+	for i, c := range t.text {
+		leafEnd = i
+		remainder++
+		var lastNew *stNode
+		for remainder > 0 {
+			if activeLen == 0 {
+				activeEdge = i
+			}
+			next, ok := activeNode.next[t.text[activeEdge]]
+			if !ok {
+				activeNode.next[t.text[activeEdge]] = &stNode{start: i, end: &leafEnd}
+				if lastNew != nil {
+					lastNew.link = activeNode
+					lastNew = nil
+				}
+			} else {
+				// walk down when the active length covers the edge
+				if l := next.edgeLen(); activeLen >= l {
+					activeEdge += l
+					activeLen -= l
+					activeNode = next
+					continue
+				}
+				// suffix already present, implicit until a later phase
+				if t.text[next.start+activeLen] == c {
+					if lastNew != nil && activeNode != t.root {
+						lastNew.link = activeNode
+					}
+					activeLen++
+					break
+				}
+
+				splitEnd := next.start + activeLen - 1
+				split := &stNode{start: next.start, end: &splitEnd,
+					link: t.root, next: map[int]*stNode{}}
+				activeNode.next[t.text[activeEdge]] = split
+				split.next[c] = &stNode{start: i, end: &leafEnd}
+				next.start += activeLen
+				split.next[t.text[next.start]] = next
+				if lastNew != nil {
+					lastNew.link = split
+				}
+				lastNew = split
+			}
+
+			remainder--
+			if activeNode == t.root && activeLen > 0 {
+				activeLen--
+				activeEdge = i - remainder + 1
+			} else if activeNode != t.root {
+				activeNode = activeNode.link
+				if activeNode == nil {
+					activeNode = t.root
+				}
+			}
+		}
+	}
+	return t
+}
+
+func (n *stNode) edgeLen() int { return *n.end - n.start + 1 }
+
+func (t *SuffixTree) label(n *stNode) string {
+	sb := strings.Builder{}
+	for _, c := range t.text[n.start : *n.end+1] {
+		if c == stTerminal {
+			sb.WriteByte('$')
+		} else {
+			sb.WriteByte(byte(c))
+		}
+	}
+	return sb.String()
+}
+
+func (t *SuffixTree) childs(n *stNode) []*stNode {
+	keys := make([]int, 0, len(n.next))
+	for k := range n.next {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	childs := make([]*stNode, len(keys))
+	for i, k := range keys {
+		childs[i] = n.next[k]
+	}
+	return childs
+}
+
+func (t *SuffixTree) preorder(n *stNode) string {
+	children := []string{}
+	for _, c := range t.childs(n) {
+		children = append(children, t.preorder(c))
+	}
+	if n == t.root {
+		return strings.Join(children, " ")
+	}
+	if len(children) == 0 {
+		return t.label(n)
+	}
+	return fmt.Sprintf("%s {%s}", t.label(n), strings.Join(children, " "))
+}
+
+// edge labels in preorder, childs in byte order with
+// the terminal symbol printed as '$' and sorted last
+func (t *SuffixTree) Visit() string { return t.preorder(t.root) }
+
+func (t *SuffixTree) Contains(sub string) bool {
+	n, i := t.root, 0
+	for i < len(sub) {
+		next, ok := n.next[int(sub[i])]
+		if !ok {
+			return false
+		}
+		for j := next.start; j <= *next.end && i < len(sub); j++ {
+			if t.text[j] != int(sub[i]) {
+				return false
+			}
+			i++
+		}
+		n = next
+	}
+	return true
+}
+
+// number of leaves, one per suffix including the empty one
+func (t *SuffixTree) Leaves() int {
+	cnt := 0
+	stack := []*stNode{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(n.next) == 0 {
+			cnt++
+		}
+		for _, c := range n.next {
+			stack = append(stack, c)
+		}
+	}
+	return cnt
+}
@@ -0,0 +1,34 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_SuffixTree(t *testing.T) {
+	tree := algo.NewSuffixTree("abab")
+	assert.Equal(t, tree.Visit(), "ab {ab$ $} b {ab$ $} $")
+	tree = algo.NewSuffixTree("banana")
+	assert.Equal(t, tree.Visit(), "a {na {na$ $} $} banana$ na {na$ $} $")
+	assert.Assert(t, tree.Contains("nan"))
+	assert.Assert(t, !tree.Contains("nab"))
+	assert.Equal(t, algo.NewSuffixTree("").Visit(), "$")
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 100; i++ {
+		text := randText(r, "abc", r.Intn(100))
+		tree := algo.NewSuffixTree(text)
+		assert.Equal(t, tree.Leaves(), len(text)+1)
+		for j := 0; j < 20; j++ {
+			sub := randText(r, "abc", 1+r.Intn(6))
+			assert.Equal(t, tree.Contains(sub), strings.Contains(text, sub))
+		}
+		for j := 0; j < len(text); j++ {
+			assert.Assert(t, tree.Contains(text[j:]))
+		}
+	}
+}
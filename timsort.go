@@ -0,0 +1,278 @@
+package algo
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	timMinMerge  = 32 // slices shorter than this are binary insertion sorted
+	timMinGallop = 7  // wins in a row before a merge starts galloping
+)
+
+type timRun struct {
+	base, len int
+}
+
+type timsorter[T any] struct {
+	s         []T
+	cmp       func(a, b T) int
+	tmp       []T
+	runs      []timRun
+	minGallop int
+}
+
+// TimSort is a stable natural merge sort: it finds runs already in
+// order (reversing strictly descending ones), extends short runs
+// with binary insertion sort and merges them under the stack
+// invariants of Tim Peters' listsort. Merges gallop when one run
+// keeps winning, so nearly sorted input takes close to linear time.
+func TimSort[T constraints.Ordered](s []T) {
+	TimSortFunc(s, cmpOrdered[T])
+}
+
+func TimSortFunc[T any](s []T, cmp func(a, b T) int) {
+	if len(s) < 2 {
+		return
+	}
+	t := &timsorter[T]{s: s, cmp: cmp, minGallop: timMinGallop}
+	minRun := timMinRun(len(s))
+	for lo := 0; lo < len(s); {
+		n := t.countRun(lo)
+		if n < minRun {
+			force := minRun
+			if force > len(s)-lo {
+				force = len(s) - lo
+			}
+			t.binaryInsertion(lo, lo+force, lo+n)
+			n = force
+		}
+		t.runs = append(t.runs, timRun{lo, n})
+		t.mergeCollapse()
+		lo += n
+	}
+	for len(t.runs) > 1 {
+		i := len(t.runs) - 2
+		if i > 0 && t.runs[i-1].len < t.runs[i+1].len {
+			i--
+		}
+		t.mergeAt(i)
+	}
+}
+
+// a minimum run length in [16, 32] that makes n/minRun a power
+// of two or slightly less, so the final merges stay balanced
+func timMinRun(n int) int {
+	r := 0
+	for n >= timMinMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+// length of the run starting at lo, a descending run must be
+// strictly descending so reversing it keeps the sort stable
+func (t *timsorter[T]) countRun(lo int) int {
+	s, hi := t.s, lo+1
+	if hi == len(s) {
+		return 1
+	}
+	if t.cmp(s[hi], s[lo]) < 0 {
+		for hi++; hi < len(s) && t.cmp(s[hi], s[hi-1]) < 0; hi++ {
+		}
+		for i, j := lo, hi-1; i < j; i, j = i+1, j-1 {
+			s[i], s[j] = s[j], s[i]
+		}
+	} else {
+		for hi++; hi < len(s) && t.cmp(s[hi], s[hi-1]) >= 0; hi++ {
+		}
+	}
+	return hi - lo
+}
+
+// sort s[lo:hi] given that s[lo:start] is already sorted, each
+// element goes after the equal ones before it
+func (t *timsorter[T]) binaryInsertion(lo, hi, start int) {
+	s := t.s
+	for i := start; i < hi; i++ {
+		pivot := s[i]
+		pos := lo + sort.Search(i-lo, func(k int) bool {
+			return t.cmp(pivot, s[lo+k]) < 0
+		})
+		copy(s[pos+1:i+1], s[pos:i])
+		s[pos] = pivot
+	}
+}
+
+// restore the invariants runs[i-2] > runs[i-1] + runs[i] and
+// runs[i-1] > runs[i] on the top of the run stack, checking one
+// level deeper than listsort originally did to fix its known flaw
+func (t *timsorter[T]) mergeCollapse() {
+	for len(t.runs) > 1 {
+		r, i := t.runs, len(t.runs)-2
+		if (i > 0 && r[i-1].len <= r[i].len+r[i+1].len) ||
+			(i > 1 && r[i-2].len <= r[i-1].len+r[i].len) {
+			if r[i-1].len < r[i+1].len {
+				i--
+			}
+		} else if r[i].len > r[i+1].len {
+			return
+		}
+		t.mergeAt(i)
+	}
+}
+
+// merge runs i and i+1, elements of the first run not greater than
+// the head of the second and elements of the second run not less
+// than the tail of the first are already in place
+func (t *timsorter[T]) mergeAt(i int) {
+	s, a, b := t.s, t.runs[i], t.runs[i+1]
+	t.runs[i].len += b.len
+	t.runs = append(t.runs[:i+1], t.runs[i+2:]...)
+
+	k := gallopRight(s[b.base], s[a.base:a.base+a.len], t.cmp, false)
+	a.base += k
+	a.len -= k
+	if a.len == 0 {
+		return
+	}
+	b.len = gallopLeft(s[a.base+a.len-1], s[b.base:b.base+b.len], t.cmp, true)
+	if b.len == 0 {
+		return
+	}
+	if a.len <= b.len {
+		t.mergeLo(a, b)
+	} else {
+		t.mergeHi(a, b)
+	}
+}
+
+// merge front to back, copying the shorter first run aside
+func (t *timsorter[T]) mergeLo(a, b timRun) {
+	s := t.s
+	t.tmp = append(t.tmp[:0], s[a.base:a.base+a.len]...)
+	tmp := t.tmp
+	i, j, dest, end := 0, b.base, a.base, b.base+b.len
+	for i < len(tmp) && j < end {
+		wins1, wins2 := 0, 0
+		for i < len(tmp) && j < end && wins1 < t.minGallop && wins2 < t.minGallop {
+			if t.cmp(s[j], tmp[i]) < 0 {
+				s[dest] = s[j]
+				j++
+				wins1, wins2 = 0, wins2+1
+			} else {
+				s[dest] = tmp[i]
+				i++
+				wins1, wins2 = wins1+1, 0
+			}
+			dest++
+		}
+		for i < len(tmp) && j < end {
+			n1 := gallopRight(s[j], tmp[i:], t.cmp, false)
+			copy(s[dest:], tmp[i:i+n1])
+			dest, i = dest+n1, i+n1
+			if i == len(tmp) {
+				break
+			}
+			n2 := gallopLeft(tmp[i], s[j:end], t.cmp, false)
+			copy(s[dest:], s[j:j+n2])
+			dest, j = dest+n2, j+n2
+			if !t.galloping(n1, n2) {
+				break
+			}
+		}
+	}
+	// whatever is left of the second run is already in place
+	copy(s[dest:], tmp[i:])
+}
+
+// merge back to front, copying the shorter second run aside
+func (t *timsorter[T]) mergeHi(a, b timRun) {
+	s := t.s
+	t.tmp = append(t.tmp[:0], s[b.base:b.base+b.len]...)
+	tmp := t.tmp
+	i, j, dest := a.base+a.len, len(tmp), b.base+b.len
+	for i > a.base && j > 0 {
+		wins1, wins2 := 0, 0
+		for i > a.base && j > 0 && wins1 < t.minGallop && wins2 < t.minGallop {
+			dest--
+			if t.cmp(tmp[j-1], s[i-1]) < 0 {
+				s[dest] = s[i-1]
+				i--
+				wins1, wins2 = wins1+1, 0
+			} else {
+				s[dest] = tmp[j-1]
+				j--
+				wins1, wins2 = 0, wins2+1
+			}
+		}
+		for i > a.base && j > 0 {
+			k := gallopRight(tmp[j-1], s[a.base:i], t.cmp, true)
+			n1 := i - a.base - k
+			copy(s[dest-n1:], s[i-n1:i])
+			dest, i = dest-n1, i-n1
+			if i == a.base {
+				break
+			}
+			k = gallopLeft(s[i-1], tmp[:j], t.cmp, true)
+			n2 := j - k
+			copy(s[dest-n2:], tmp[k:j])
+			dest, j = dest-n2, k
+			if !t.galloping(n1, n2) {
+				break
+			}
+		}
+	}
+	// whatever is left of the first run is already in place
+	copy(s[dest-j:], tmp[:j])
+}
+
+// galloping pays off the longer it lasts, so it gets cheaper to
+// enter while it copies long stretches and dearer once it stops
+func (t *timsorter[T]) galloping(n1, n2 int) bool {
+	if n1 < timMinGallop && n2 < timMinGallop {
+		t.minGallop += 2
+		return false
+	}
+	if t.minGallop > 1 {
+		t.minGallop--
+	}
+	return true
+}
+
+// gallopLeft returns the number of elements of sorted s less than
+// key, gallopRight the number not greater than key
+func gallopLeft[T any](key T, s []T, cmp func(a, b T) int, fromEnd bool) int {
+	return gallop(len(s), func(i int) bool { return cmp(s[i], key) >= 0 }, fromEnd)
+}
+
+func gallopRight[T any](key T, s []T, cmp func(a, b T) int, fromEnd bool) int {
+	return gallop(len(s), func(i int) bool { return cmp(s[i], key) > 0 }, fromEnd)
+}
+
+// smallest i in [0, n] where the monotone pred holds, probing 1,
+// 2, 4 ... elements from the chosen end before a binary search,
+// so an answer d elements away costs O(log d) comparisons
+func gallop(n int, pred func(i int) bool, fromEnd bool) int {
+	lo, hi, ofs := 0, n, 1
+	if fromEnd {
+		for ofs <= n && pred(n-ofs) {
+			hi = n - ofs
+			ofs *= 2
+		}
+		if ofs <= n {
+			lo = n - ofs + 1
+		}
+	} else {
+		for ofs <= n && !pred(ofs-1) {
+			lo = ofs
+			ofs *= 2
+		}
+		if ofs <= n {
+			hi = ofs - 1
+		}
+	}
+	return lo + sort.Search(hi-lo, func(k int) bool { return pred(lo + k) })
+}
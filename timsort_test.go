@@ -0,0 +1,59 @@
+package algo_test
+
+import (
+	"algo"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_TimSort(t *testing.T) {
+	n := 10000
+	sorted := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+	}
+
+	// already sorted and strictly descending input is a single run
+	reversed := make([]int, n)
+	for i := range reversed {
+		reversed[i] = n - 1 - i
+	}
+	for _, in := range [][]int{sorted, reversed} {
+		s := append([]int{}, in...)
+		ncmp := 0
+		algo.TimSortFunc(s, func(a, b int) int { ncmp++; return a - b })
+		assert.DeepEqual(t, s, sorted)
+		assert.Assert(t, ncmp < n, ncmp)
+	}
+
+	// two runs interleaved in long blocks, finding them takes n
+	// comparisons and galloping keeps the merge far below that
+	s := []int{}
+	for i := 0; i < n; i += 1000 {
+		s = append(s, sorted[i:i+500]...)
+	}
+	for i := 500; i < n; i += 1000 {
+		s = append(s, sorted[i:i+500]...)
+	}
+	ncmp := 0
+	algo.TimSortFunc(s, func(a, b int) int { ncmp++; return a - b })
+	assert.DeepEqual(t, s, sorted)
+	assert.Assert(t, ncmp < n+n/20, ncmp)
+
+	// many short runs of duplicates
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 100; k++ {
+		s := make([]int, rnd.Intn(2000))
+		for i := range s {
+			s[i] = rnd.Intn(1 + rnd.Intn(20))
+			if rnd.Intn(4) > 0 && i > 0 {
+				s[i] = s[i-1] + rnd.Intn(2)
+			}
+		}
+		algo.TimSort(s)
+		assert.Assert(t, sort.IntsAreSorted(s))
+	}
+}
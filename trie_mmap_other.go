@@ -0,0 +1,14 @@
+//go:build !unix
+
+package algo
+
+import "os"
+
+// no mmap support, fall back to reading the whole file
+func mapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
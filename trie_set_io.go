@@ -0,0 +1,297 @@
+package algo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// binary layout of a serialized TrieSet:
+//
+//	"TRIE" version | nodes ... | root offset (uint64 LE)
+//
+// nodes are written in post order so a node only refers back to
+// its childs, each node is
+//
+//	flags | uvarint child count | (char, uvarint offset delta) ...
+//
+// with childs in char order and delta = node offset - child offset,
+// root offset 0 means an empty set
+const (
+	trieMagic      = "TRIE"
+	trieVersion    = 1
+	trieHeaderLen  = len(trieMagic) + 1
+	trieTrailerLen = 8
+	trieIsString   = 1
+)
+
+var badTrieDataErr = fmt.Errorf("malformed trie data")
+
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *countWriter) Write(p []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (n *trieNode) write(w *countWriter) uint64 {
+	offs := [CharNum]uint64{}
+	cnt := uint64(0)
+	for i, c := range n.next {
+		if c != nil {
+			offs[i] = c.write(w)
+			cnt++
+		}
+	}
+
+	off := uint64(w.n)
+	flags := byte(0)
+	if n.isString {
+		flags |= trieIsString
+	}
+	buf := binary.AppendUvarint([]byte{flags}, cnt)
+	for i, c := range n.next {
+		if c != nil {
+			buf = append(buf, byte(i))
+			buf = binary.AppendUvarint(buf, off-offs[i])
+		}
+	}
+	w.Write(buf)
+	return off
+}
+
+func (s *TrieSet) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	cw.Write(append([]byte(trieMagic), trieVersion))
+	root := uint64(0)
+	if s.root != nil {
+		root = s.root.write(cw)
+	}
+	cw.Write(binary.LittleEndian.AppendUint64(nil, root))
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// serialized nodes, every accessor checks bounds so
+// corrupted input yields errors instead of panics
+type trieData []byte
+
+func parseTrieData(data []byte) (trieData, uint64, error) {
+	if len(data) < trieHeaderLen+trieTrailerLen ||
+		string(data[:len(trieMagic)]) != trieMagic {
+		return nil, 0, badTrieDataErr
+	}
+	if data[len(trieMagic)] != trieVersion {
+		return nil, 0, fmt.Errorf("unsupported trie version %d", data[len(trieMagic)])
+	}
+	end := len(data) - trieTrailerLen
+	root := binary.LittleEndian.Uint64(data[end:])
+	if root != 0 && (root < uint64(trieHeaderLen) || root >= uint64(end)) {
+		return nil, 0, badTrieDataErr
+	}
+	return trieData(data[:end]), root, nil
+}
+
+func (d trieData) header(o uint64) (isString bool, cnt, p uint64, err error) {
+	if o < uint64(trieHeaderLen) || o >= uint64(len(d)) {
+		return false, 0, 0, badTrieDataErr
+	}
+	cnt, k := binary.Uvarint(d[o+1:])
+	if k <= 0 {
+		return false, 0, 0, badTrieDataErr
+	}
+	return d[o]&trieIsString != 0, cnt, o + 1 + uint64(k), nil
+}
+
+// call fn on every child of the node at offset o in char order,
+// until fn returns false. Child offsets strictly decrease so a
+// walk over corrupted data always terminates.
+func (d trieData) each(o uint64, fn func(c byte, child uint64) bool) error {
+	_, cnt, p, err := d.header(o)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < cnt; i++ {
+		if p >= uint64(len(d)) {
+			return badTrieDataErr
+		}
+		c := d[p]
+		delta, k := binary.Uvarint(d[p+1:])
+		if k <= 0 || delta == 0 || delta > o-uint64(trieHeaderLen) {
+			return badTrieDataErr
+		}
+		p += 1 + uint64(k)
+		if !fn(c, o-delta) {
+			break
+		}
+	}
+	return nil
+}
+
+// offset of the child of o for char c, 0 if there is none
+func (d trieData) child(o uint64, c byte) (uint64, error) {
+	found := uint64(0)
+	err := d.each(o, func(ch byte, child uint64) bool {
+		if ch == c {
+			found = child
+		}
+		return ch < c
+	})
+	if err != nil {
+		return 0, err
+	}
+	return found, nil
+}
+
+// offset of the node reached from o by s, 0 if there is none
+func (d trieData) get(o uint64, s []byte) (uint64, error) {
+	for _, c := range s {
+		next, err := d.child(o, c)
+		if next == 0 {
+			return 0, err
+		}
+		o = next
+	}
+	return o, nil
+}
+
+func (d trieData) collect(o uint64, path []byte, results []string) ([]string, error) {
+	isString, _, _, err := d.header(o)
+	if err != nil {
+		return nil, err
+	}
+	if isString {
+		results = append(results, string(path))
+	}
+	eerr := d.each(o, func(c byte, child uint64) bool {
+		results, err = d.collect(child, append(path, c), results)
+		return err == nil
+	})
+	if eerr != nil {
+		return nil, eerr
+	}
+	return results, err
+}
+
+func (d trieData) decode(o uint64) (*trieNode, error) {
+	isString, _, _, err := d.header(o)
+	if err != nil {
+		return nil, err
+	}
+	n := &trieNode{isString: isString}
+	eerr := d.each(o, func(c byte, child uint64) bool {
+		if c >= CharNum {
+			err = badTrieDataErr
+			return false
+		}
+		n.next[c], err = d.decode(child)
+		return err == nil
+	})
+	if eerr != nil {
+		return nil, eerr
+	}
+	return n, err
+}
+
+func (s *TrieSet) ReadFrom(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(data)), err
+	}
+	d, root, err := parseTrieData(data)
+	if err != nil {
+		return int64(len(data)), err
+	}
+	// decode fully before replacing the contents, so
+	// malformed input leaves the set unchanged
+	var n *trieNode
+	if root != 0 {
+		if n, err = d.decode(root); err != nil {
+			return int64(len(data)), err
+		}
+	}
+	s.root = n
+	return int64(len(data)), nil
+}
+
+// TrieView answers read only TrieSet queries directly from
+// serialized data, without rebuilding the trie nodes
+type TrieView struct {
+	data  trieData
+	root  uint64
+	close func() error
+}
+
+func NewTrieView(data []byte) (*TrieView, error) {
+	d, root, err := parseTrieData(data)
+	if err != nil {
+		return nil, err
+	}
+	return &TrieView{data: d, root: root}, nil
+}
+
+// OpenTrieView memory maps a file written by TrieSet.WriteTo,
+// the view must be closed to release the mapping
+func OpenTrieView(path string) (*TrieView, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := NewTrieView(data)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	v.close = unmap
+	return v, nil
+}
+
+func (v *TrieView) Close() error {
+	if v.close == nil {
+		return nil
+	}
+	err := v.close()
+	v.close, v.data, v.root = nil, nil, 0
+	return err
+}
+
+func (v *TrieView) IsEmpty() bool {
+	return v.root == 0
+}
+
+// Contains reports whether key is in the set, the data is only
+// validated along the way, so an error means the part of the
+// mapping visited for key is corrupt
+func (v *TrieView) Contains(key string) (bool, error) {
+	if v.root == 0 {
+		return false, nil
+	}
+	o, err := v.data.get(v.root, []byte(key))
+	if o == 0 {
+		return false, err
+	}
+	isString, _, _, err := v.data.header(o)
+	return err == nil && isString, err
+}
+
+func (v *TrieView) KeysWithPrefix(prefix string) ([]string, error) {
+	if v.root == 0 {
+		return nil, nil
+	}
+	o, err := v.data.get(v.root, []byte(prefix))
+	if o == 0 {
+		return nil, err
+	}
+	return v.data.collect(o, []byte(prefix), []string{})
+}
@@ -0,0 +1,146 @@
+package algo_test
+
+import (
+	"algo"
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_TrieSetIO(t *testing.T) {
+	set := algo.TrieSet{}
+	keys := []string{"", "a", "mill", "million"}
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, fmt.Sprintf("%x", rand.Intn(5000)))
+	}
+	for _, k := range keys {
+		assert.NilError(t, set.Put(k))
+	}
+
+	buf := bytes.Buffer{}
+	n, err := set.WriteTo(&buf)
+	assert.NilError(t, err)
+	assert.Equal(t, n, int64(buf.Len()))
+	data := buf.Bytes()
+
+	loaded := algo.TrieSet{}
+	n, err = loaded.ReadFrom(bytes.NewReader(data))
+	assert.NilError(t, err)
+	assert.Equal(t, n, int64(len(data)))
+
+	view, err := algo.NewTrieView(data)
+	assert.NilError(t, err)
+	for _, k := range keys {
+		assert.Assert(t, loaded.Contains(k))
+		ok, err := view.Contains(k)
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+	}
+	for _, k := range []string{"mil", "zzz"} {
+		ok, err := view.Contains(k)
+		assert.NilError(t, err)
+		assert.Assert(t, !ok)
+	}
+	for _, p := range []string{"", "1", "a", "mi", "ff", "zzz"} {
+		assert.DeepEqual(t, loaded.KeysWithPrefix(p), set.KeysWithPrefix(p))
+		viewKeys, err := view.KeysWithPrefix(p)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, viewKeys, set.KeysWithPrefix(p))
+	}
+
+	path := filepath.Join(t.TempDir(), "trie.bin")
+	assert.NilError(t, os.WriteFile(path, data, 0o644))
+	mapped, err := algo.OpenTrieView(path)
+	assert.NilError(t, err)
+	mappedKeys, err := mapped.KeysWithPrefix("mi")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, mappedKeys, []string{"mill", "million"})
+	assert.NilError(t, mapped.Close())
+
+	empty := algo.TrieSet{}
+	buf.Reset()
+	_, err = empty.WriteTo(&buf)
+	assert.NilError(t, err)
+	view, err = algo.NewTrieView(buf.Bytes())
+	assert.NilError(t, err)
+	assert.Assert(t, view.IsEmpty())
+	_, err = loaded.ReadFrom(bytes.NewReader(buf.Bytes()))
+	assert.NilError(t, err)
+	assert.Assert(t, loaded.IsEmpty())
+
+	_, err = loaded.ReadFrom(bytes.NewReader([]byte("JUNK")))
+	assert.Error(t, err, "malformed trie data")
+	bad := append([]byte{}, data...)
+	bad[4] = 9
+	_, err = algo.NewTrieView(bad)
+	assert.Error(t, err, "unsupported trie version 9")
+	for i := 0; i < 100; i++ {
+		bad := append([]byte{}, data...)
+		bad[5+rand.Intn(len(bad)-13)] ^= byte(1 + rand.Intn(255))
+		// must not panic
+		loaded.ReadFrom(bytes.NewReader(bad))
+		if view, err := algo.NewTrieView(bad); err == nil {
+			view.KeysWithPrefix("")
+		}
+	}
+}
+
+func TestAlgo_TrieSetIOCorrupt(t *testing.T) {
+	set := algo.TrieSet{}
+	assert.NilError(t, set.Put("keep"))
+	buf := bytes.Buffer{}
+	_, err := set.WriteTo(&buf)
+	assert.NilError(t, err)
+	data := buf.Bytes()
+
+	// the root is written last, its only child offset
+	// delta ends right before the trailer
+	bad := append([]byte{}, data...)
+	bad[len(bad)-9] = 0
+	_, err = set.ReadFrom(bytes.NewReader(bad))
+	assert.Error(t, err, "malformed trie data")
+	assert.Assert(t, set.Contains("keep"))
+
+	view, err := algo.NewTrieView(bad)
+	assert.NilError(t, err)
+	ok, err := view.Contains("keep")
+	assert.Error(t, err, "malformed trie data")
+	assert.Assert(t, !ok)
+	keys, err := view.KeysWithPrefix("")
+	assert.Error(t, err, "malformed trie data")
+	assert.Assert(t, keys == nil)
+}
+
+func trieSetBytes(b *testing.B) []byte {
+	set := algo.TrieSet{}
+	for _, k := range urlKeys(1000) {
+		set.Put(k)
+	}
+	buf := bytes.Buffer{}
+	if _, err := set.WriteTo(&buf); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkLoad_TrieSet(b *testing.B) {
+	data := trieSetBytes(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set := algo.TrieSet{}
+		set.ReadFrom(bytes.NewReader(data))
+	}
+}
+
+func BenchmarkLoad_TrieView(b *testing.B) {
+	data := trieSetBytes(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		algo.NewTrieView(data)
+	}
+}
@@ -0,0 +1,238 @@
+package algo
+
+import "fmt"
+
+var emptyKeyErr = fmt.Errorf("key must not be empty")
+
+// ternary search tree node, left/right link nodes holding other
+// chars at the same depth, mid links to the next char of the key
+type tstNode[V any] struct {
+	c        rune
+	left     *tstNode[V]
+	mid      *tstNode[V]
+	right    *tstNode[V]
+	val      V
+	isString bool
+}
+
+func (n *tstNode[V]) get(s []rune, d int) *tstNode[V] {
+	if n == nil {
+		return nil
+	}
+	if s[d] < n.c {
+		return n.left.get(s, d)
+	}
+	if s[d] > n.c {
+		return n.right.get(s, d)
+	}
+	if d < len(s)-1 {
+		return n.mid.get(s, d+1)
+	}
+	return n
+}
+
+// fix, if not nil, is called on every node whose subtree changed,
+// children before parents, so callers can keep per node aggregates
+func (n *tstNode[V]) add(s []rune, d int, val V, fix func(*tstNode[V])) *tstNode[V] {
+	if n == nil {
+		n = &tstNode[V]{c: s[d]}
+	}
+	if s[d] < n.c {
+		n.left = n.left.add(s, d, val, fix)
+	} else if s[d] > n.c {
+		n.right = n.right.add(s, d, val, fix)
+	} else if d < len(s)-1 {
+		n.mid = n.mid.add(s, d+1, val, fix)
+	} else {
+		n.val = val
+		n.isString = true
+	}
+	if fix != nil {
+		fix(n)
+	}
+	return n
+}
+
+func (n *tstNode[V]) remove(s []rune, d int, fix func(*tstNode[V])) *tstNode[V] {
+	if n == nil {
+		return nil
+	}
+	if s[d] < n.c {
+		n.left = n.left.remove(s, d, fix)
+	} else if s[d] > n.c {
+		n.right = n.right.remove(s, d, fix)
+	} else if d < len(s)-1 {
+		n.mid = n.mid.remove(s, d+1, fix)
+	} else {
+		var zero V
+		n.val = zero
+		n.isString = false
+	}
+
+	if n.isString || n.mid != nil {
+		if fix != nil {
+			fix(n)
+		}
+		return n
+	}
+
+	// node is no longer on any key path, splice its siblings
+	// together, all chars in right are larger than those in left
+	if n.left == nil {
+		return n.right
+	}
+	if n.right != nil {
+		max := n.left
+		for max.right != nil {
+			max = max.right
+		}
+		max.right = n.right
+		if fix != nil {
+			n.left.fixSpine(max, fix)
+		}
+	}
+	return n.left
+}
+
+// fix the right spine of n down to last, which gained the
+// siblings of a spliced out node, bottom up
+func (n *tstNode[V]) fixSpine(last *tstNode[V], fix func(*tstNode[V])) {
+	if n != last {
+		n.right.fixSpine(last, fix)
+	}
+	fix(n)
+}
+
+func (n *tstNode[V]) collect(prefix []rune, results []string) []string {
+	if n == nil {
+		return results
+	}
+	results = n.left.collect(prefix, results)
+	prefix = append(prefix, n.c)
+	if n.isString {
+		results = append(results, string(prefix))
+	}
+	results = n.mid.collect(prefix, results)
+	return n.right.collect(prefix[:len(prefix)-1], results)
+}
+
+// '.' in pattern matches any single char
+func (n *tstNode[V]) match(prefix, pat []rune, results []string) []string {
+	if n == nil {
+		return results
+	}
+	d := len(prefix)
+	c := pat[d]
+	if c == '.' || c < n.c {
+		results = n.left.match(prefix, pat, results)
+	}
+	if c == '.' || c == n.c {
+		prefix = append(prefix, n.c)
+		if d == len(pat)-1 && n.isString {
+			results = append(results, string(prefix))
+		}
+		if d < len(pat)-1 {
+			results = n.mid.match(prefix, pat, results)
+		}
+		prefix = prefix[:d]
+	}
+	if c == '.' || c > n.c {
+		results = n.right.match(prefix, pat, results)
+	}
+	return results
+}
+
+// TST is a ternary search tree symbol table, keys can be
+// any unicode string, memory grows with the keys stored
+// rather than the alphabet size
+type TST[V any] struct {
+	root *tstNode[V]
+	size int
+}
+
+func (t *TST[V]) IsEmpty() bool { return t.root == nil }
+
+func (t *TST[V]) Size() int { return t.size }
+
+func (t *TST[V]) Get(key string) (V, bool) {
+	var zero V
+	if key == "" {
+		return zero, false
+	}
+	n := t.root.get([]rune(key), 0)
+	if n == nil || !n.isString {
+		return zero, false
+	}
+	return n.val, true
+}
+
+func (t *TST[V]) Contains(key string) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+func (t *TST[V]) Put(key string, val V) error {
+	if key == "" {
+		return emptyKeyErr
+	}
+	if !t.Contains(key) {
+		t.size++
+	}
+	t.root = t.root.add([]rune(key), 0, val, nil)
+	return nil
+}
+
+func (t *TST[V]) Delete(key string) error {
+	if key == "" {
+		return emptyKeyErr
+	}
+	if !t.Contains(key) {
+		return nil
+	}
+	t.size--
+	t.root = t.root.remove([]rune(key), 0, nil)
+	return nil
+}
+
+func (t *TST[V]) KeysWithPrefix(prefix string) []string {
+	if prefix == "" {
+		return t.root.collect(nil, []string{})
+	}
+	pre := []rune(prefix)
+	n := t.root.get(pre, 0)
+	if n == nil {
+		return nil
+	}
+	results := []string{}
+	if n.isString {
+		results = append(results, prefix)
+	}
+	return n.mid.collect(pre, results)
+}
+
+// longest key in the tree that is a prefix of query
+func (t *TST[V]) LongestPrefixOf(query string) string {
+	s := []rune(query)
+	n, d, length := t.root, 0, 0
+	for n != nil && d < len(s) {
+		if s[d] < n.c {
+			n = n.left
+		} else if s[d] > n.c {
+			n = n.right
+		} else {
+			d++
+			if n.isString {
+				length = d
+			}
+			n = n.mid
+		}
+	}
+	return string(s[:length])
+}
+
+func (t *TST[V]) KeysThatMatch(pattern string) []string {
+	if pattern == "" {
+		return []string{}
+	}
+	return t.root.match(nil, []rune(pattern), []string{})
+}
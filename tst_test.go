@@ -0,0 +1,93 @@
+package algo_test
+
+import (
+	"algo"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_TST(t *testing.T) {
+	tst := algo.TST[int]{}
+	assert.Assert(t, tst.IsEmpty())
+
+	assert.Error(t, tst.Put("", 0), "key must not be empty")
+	for i, k := range []string{"she", "sells", "sea", "shells", "by", "the", "sea", "shore", "你好"} {
+		assert.NilError(t, tst.Put(k, i))
+	}
+	assert.Equal(t, tst.Size(), 8)
+	v, ok := tst.Get("sea")
+	assert.Assert(t, ok && v == 6)
+	_, ok = tst.Get("se")
+	assert.Assert(t, !ok)
+	assert.Assert(t, tst.Contains("你好"))
+
+	assert.DeepEqual(t, tst.KeysWithPrefix("sh"), []string{"she", "shells", "shore"})
+	assert.DeepEqual(t, tst.KeysWithPrefix("she"), []string{"she", "shells"})
+	assert.Assert(t, tst.KeysWithPrefix("x") == nil)
+	assert.Equal(t, tst.LongestPrefixOf("shellsort"), "shells")
+	assert.Equal(t, tst.LongestPrefixOf("shel"), "she")
+	assert.Equal(t, tst.LongestPrefixOf("quicksort"), "")
+	assert.Equal(t, tst.LongestPrefixOf("你好吗"), "你好")
+	assert.DeepEqual(t, tst.KeysThatMatch(".he"), []string{"she", "the"})
+	assert.DeepEqual(t, tst.KeysThatMatch("s..."), []string{})
+	assert.DeepEqual(t, tst.KeysThatMatch("你."), []string{"你好"})
+
+	keys := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		k := fmt.Sprintf("%x", rand.Intn(5000))
+		keys[k] = i
+		assert.NilError(t, tst.Put(k, i))
+	}
+	all := tst.KeysWithPrefix("")
+	assert.Assert(t, slices.IsSorted(all))
+	assert.Equal(t, len(all), tst.Size())
+	for k, i := range keys {
+		v, ok := tst.Get(k)
+		assert.Assert(t, ok && v == i)
+		assert.NilError(t, tst.Delete(k))
+		assert.Assert(t, !tst.Contains(k))
+	}
+	for _, k := range []string{"she", "sells", "sea", "shells", "by", "the", "shore", "你好"} {
+		assert.NilError(t, tst.Delete(k))
+	}
+	assert.Assert(t, tst.IsEmpty())
+	assert.Equal(t, tst.Size(), 0)
+}
+
+func BenchmarkMem_TST(b *testing.B) {
+	benchmarkTrieMem(b, urlKeys(1000), func(keys []string) any {
+		tst := &algo.TST[struct{}]{}
+		for _, k := range keys {
+			tst.Put(k, struct{}{})
+		}
+		return tst
+	})
+}
+
+func BenchmarkContains_TrieSet(b *testing.B) {
+	keys := urlKeys(1000)
+	set := &algo.TrieSet{}
+	for _, k := range keys {
+		set.Put(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.Contains(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkContains_TST(b *testing.B) {
+	keys := urlKeys(1000)
+	tst := &algo.TST[struct{}]{}
+	for _, k := range keys {
+		tst.Put(k, struct{}{})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tst.Contains(keys[i%len(keys)])
+	}
+}
@@ -0,0 +1,97 @@
+package algo
+
+// maximal suffix of pat for the byte order, or the reversed
+// order if rev is set, returns the position before the suffix
+// and the period of the suffix
+func maximalSuffix(pat []byte, rev bool) (int, int) {
+	ms, j, k, p := -1, 0, 1, 1
+	for j+k < len(pat) {
+		a, b := pat[j+k], pat[ms+k]
+		if (!rev && a < b) || (rev && a > b) {
+			j += k
+			k = 1
+			p = j - ms
+		} else if a == b {
+			if k != p {
+				k++
+			} else {
+				j += p
+				k = 1
+			}
+		} else {
+			ms = j
+			j = ms + 1
+			k, p = 1, 1
+		}
+	}
+	return ms, p
+}
+
+// TwoWay is the Crochemore-Perrin algorithm, the pattern is split
+// at a critical factorization, the right part is matched left to
+// right then the left part right to left, in constant extra space
+type TwoWay struct {
+	pat      []byte
+	ell      int // last index of the left part
+	per      int
+	periodic bool
+}
+
+func NewTwoWay(pattern string) (*TwoWay, error) {
+	if pattern == "" {
+		return nil, emptyPatternErr
+	}
+	tw := &TwoWay{pat: []byte(pattern)}
+	i, p := maximalSuffix(tw.pat, false)
+	j, q := maximalSuffix(tw.pat, true)
+	if i > j {
+		tw.ell, tw.per = i, p
+	} else {
+		tw.ell, tw.per = j, q
+	}
+
+	m := len(tw.pat)
+	tw.periodic = tw.per+tw.ell+1 <= m &&
+		string(tw.pat[:tw.ell+1]) == string(tw.pat[tw.per:tw.per+tw.ell+1])
+	if !tw.periodic {
+		tw.per = tw.ell + 1
+		if m-tw.ell-1 > tw.per {
+			tw.per = m - tw.ell - 1
+		}
+		tw.per++
+	}
+	return tw, nil
+}
+
+func (tw *TwoWay) FindAll(s string) []int {
+	pos, m, x := []int{}, len(tw.pat), tw.pat
+	// memory is the prefix length known to match after a period shift
+	memory := -1
+	for j := 0; j <= len(s)-m; {
+		i := tw.ell + 1
+		if tw.periodic && memory > tw.ell {
+			i = memory + 1
+		}
+		for i < m && x[i] == s[i+j] {
+			i++
+		}
+		if i < m {
+			j += i - tw.ell
+			memory = -1
+			continue
+		}
+
+		i = tw.ell
+		for i > memory && x[i] == s[i+j] {
+			i--
+		}
+		if i <= memory {
+			pos = append(pos, j)
+		}
+		j += tw.per
+		if tw.periodic {
+			memory = m - tw.per - 1
+		}
+	}
+	return pos
+}
@@ -0,0 +1,65 @@
+package algo
+
+// ZFunction returns z where z[i] is the length of the longest
+// common prefix of s and s[i:], z[0] is len(s)
+func ZFunction(s string) []int {
+	z := make([]int, len(s))
+	if len(s) == 0 {
+		return z
+	}
+	z[0] = len(s)
+	l, r := 0, 0 // s[l:r] == s[:r-l], rightmost such window
+	for i := 1; i < len(s); i++ {
+		if i < r {
+			z[i] = z[i-l]
+			if z[i] > r-i {
+				z[i] = r - i
+			}
+		}
+		for i+z[i] < len(s) && s[z[i]] == s[i+z[i]] {
+			z[i]++
+		}
+		if i+z[i] > r {
+			l, r = i, i+z[i]
+		}
+	}
+	return z
+}
+
+// ZSearch matches the text against the pattern Z array,
+// reusing known prefix matches the same way ZFunction does
+type ZSearch struct {
+	pat string
+	z   []int
+}
+
+func NewZSearch(pattern string) (*ZSearch, error) {
+	if pattern == "" {
+		return nil, emptyPatternErr
+	}
+	return &ZSearch{pat: pattern, z: ZFunction(pattern)}, nil
+}
+
+func (zs *ZSearch) FindAll(s string) []int {
+	pos, m := []int{}, len(zs.pat)
+	l, r := 0, 0 // s[l:r] == pat[:r-l]
+	for i := 0; i <= len(s)-m; i++ {
+		k := 0
+		if i < r {
+			if zs.z[i-l] < r-i {
+				continue
+			}
+			k = r - i
+		}
+		for k < m && s[i+k] == zs.pat[k] {
+			k++
+		}
+		if i+k > r {
+			l, r = i, i+k
+		}
+		if k == m {
+			pos = append(pos, i)
+		}
+	}
+	return pos
+}
@@ -0,0 +1,15 @@
+package algo_test
+
+import (
+	"algo"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlgo_ZFunction(t *testing.T) {
+	assert.DeepEqual(t, algo.ZFunction(""), []int{})
+	assert.DeepEqual(t, algo.ZFunction("aaaaa"), []int{5, 4, 3, 2, 1})
+	assert.DeepEqual(t, algo.ZFunction("aabxaab"), []int{7, 1, 0, 0, 3, 1, 0})
+	assert.DeepEqual(t, algo.ZFunction("abacaba"), []int{7, 0, 1, 0, 3, 0, 1})
+}